@@ -7,7 +7,12 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/raonismaneoto/CustomDHT/commons/grpc_api"
 	"github.com/raonismaneoto/CustomDHT/commons/helpers"
+	"github.com/raonismaneoto/CustomDHT/core/metrics"
+	"github.com/raonismaneoto/CustomDHT/core/models"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 	"log"
 	"net/http"
 	"os"
@@ -18,7 +23,7 @@ import (
 type HttpServer struct {
 	rootNodeAddress string
 	rootNodeId      int64
-	m               int
+	owners          *ownerCache
 }
 
 // http api implementation
@@ -27,16 +32,15 @@ func main() {
 	port := os.Getenv("PORT")
 	rootNodeAddress := os.Getenv("ROOT_NODE_ADDR")
 	rootNodeId, err := strconv.ParseInt(os.Getenv("ROOT_NODE_ID"), 10, 64)
-	m, err := strconv.Atoi(os.Getenv("M"))
 
 	if err != nil {
-		panic("m must be an integer")
+		panic("rootNodeId must be an integer")
 	}
 
 	httpServer := HttpServer{
 		rootNodeAddress: rootNodeAddress,
 		rootNodeId:      rootNodeId,
-		m:               m,
+		owners:          newOwnerCache(rootNodeAddress),
 	}
 
 	server := &http.Server{
@@ -54,8 +58,13 @@ func handler(httpServer HttpServer) *mux.Router {
 
 	router.HandleFunc("/api/version", httpServer.version).Methods(http.MethodGet)
 	router.HandleFunc("/api/dht", httpServer.save).Methods(http.MethodPut)
+	router.HandleFunc("/api/dht/range", httpServer.rangeQuery).Methods(http.MethodGet)
 	router.HandleFunc("/api/dht/{id}", httpServer.remove).Methods(http.MethodDelete)
 	router.HandleFunc("/api/dht/{id}", httpServer.retrieve).Methods(http.MethodGet)
+	router.HandleFunc("/api/admin/migration", httpServer.migrate).Methods(http.MethodPost)
+	router.HandleFunc("/api/admin/migration", httpServer.migrationStatus).Methods(http.MethodGet)
+	router.HandleFunc("/api/admin/state", httpServer.nodeState).Methods(http.MethodGet)
+	router.HandleFunc("/api/admin/stats", httpServer.stats).Methods(http.MethodGet)
 
 	return router
 }
@@ -83,7 +92,12 @@ func (s *HttpServer) save(w http.ResponseWriter, r *http.Request) {
 
 	log.Println("Save request received. Key: " + fmt.Sprintf("%v", key))
 
-	Save(s.rootNodeAddress, helpers.GetHash(fmt.Sprintf("%v", key), s.m), []byte(fmt.Sprintf("%v", value)))
+	consistency := r.URL.Query().Get("consistency")
+	err = s.saveThroughOwner(fmt.Sprintf("%v", key), []byte(fmt.Sprintf("%v", value)), consistency)
+	if code := consistencyStatus(consistency, err); code != 0 {
+		writeError(w, code, err)
+		return
+	}
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
@@ -102,7 +116,12 @@ func (s *HttpServer) remove(w http.ResponseWriter, r *http.Request) {
 
 	log.Println("Remove request received. Key: " + fmt.Sprintf("%v", id))
 
-	Remove(s.rootNodeAddress, helpers.GetHash(id, s.m))
+	consistency := r.URL.Query().Get("consistency")
+	_, err := Remove(s.rootNodeAddress, id, consistency)
+	if code := consistencyStatus(consistency, err); code != 0 {
+		writeError(w, code, err)
+		return
+	}
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
@@ -121,14 +140,192 @@ func (s *HttpServer) retrieve(w http.ResponseWriter, r *http.Request) {
 
 	log.Println("Retrieval request received. Key: " + fmt.Sprintf("%v", id))
 
-	response := Query(s.rootNodeAddress, helpers.GetHash(id, s.m))
+	iterative := r.URL.Query().Get("lookup") == "iterative"
+	consistency := r.URL.Query().Get("consistency")
+	response, err := s.queryThroughOwner(id, iterative, consistency)
+	if code := consistencyStatus(consistency, err); code != 0 {
+		writeError(w, code, err)
+		return
+	}
 
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(string(response.Data))
 }
 
-func Query(address string, key int64) *grpc_api.QueryResponse {
+// queryThroughOwner sends the query straight to the cached owner of key,
+// going through the root node when there is none or it turns the query down.
+func (s *HttpServer) queryThroughOwner(key string, iterative bool, consistency string) (*grpc_api.QueryResponse, error) {
+	if helpers.IsOrderedKey(key) {
+		return Query(s.rootNodeAddress, key, iterative, consistency)
+	}
+	if owner, ok := s.owners.get(key); ok {
+		response, err := DirectQuery(owner, key, consistency)
+		if err == nil || status.Code(err) == codes.InvalidArgument || models.IsConsistencyError(err) {
+			return response, err
+		}
+		log.Println("cached owner " + owner + " failed: " + err.Error())
+		s.owners.invalidate(owner)
+	}
+
+	response, err := Query(s.rootNodeAddress, key, iterative, consistency)
+	s.owners.put(response)
+	return response, err
+}
+
+func (s *HttpServer) saveThroughOwner(key string, value []byte, consistency string) error {
+	if owner, ok := s.owners.get(key); ok && !helpers.IsOrderedKey(key) {
+		err := DirectSave(owner, key, value, consistency)
+		// the owner applied a write missing its consistency level
+		if err == nil || status.Code(err) == codes.InvalidArgument || models.IsConsistencyError(err) {
+			return err
+		}
+		log.Println("cached owner " + owner + " failed: " + err.Error())
+		s.owners.invalidate(owner)
+	}
+
+	_, err := Save(s.rootNodeAddress, key, value, consistency)
+	return err
+}
+
+// consistencyStatus is the http status of a request asking for a
+// consistency level that failed with err, 0 when there is none. Requests
+// without one keep answering as if they went through.
+func consistencyStatus(consistency string, err error) int {
+	if err == nil || consistency == "" {
+		return 0
+	}
+	switch status.Code(err) {
+	case codes.InvalidArgument:
+		return http.StatusBadRequest
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusInternalServerError
+}
+
+func writeError(w http.ResponseWriter, code int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(status.Convert(err).Message())
+}
+
+func (s *HttpServer) rangeQuery(w http.ResponseWriter, r *http.Request) {
+	params := r.URL.Query()
+	namespace := params.Get("namespace")
+	start := params.Get("start")
+	end := params.Get("end")
+
+	if namespace == "" || start == "" || end == "" {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Missing namespace, start or end query parameter")
+		return
+	}
+
+	limit := 0
+	if params.Get("limit") != "" {
+		parsedLimit, err := strconv.Atoi(params.Get("limit"))
+		if err != nil {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode("limit must be an integer")
+			return
+		}
+		limit = parsedLimit
+	}
+
+	log.Println("Range request received. Namespace: " + namespace + ", start: " + start + ", end: " + end)
+
+	response, err := RangeQuery(s.rootNodeAddress, namespace, start, end, limit)
+	if err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+
+	items := make([]map[string]string, 0, len(response.Items))
+	for _, item := range response.Items {
+		items = append(items, map[string]string{"key": item.Key, "value": string(item.Value)})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(items)
+}
+
+func (s *HttpServer) migrate(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		M             int    `json:"m"`
+		HashAlgorithm string `json:"hashAlgorithm"`
+	}
+	err := json.NewDecoder(r.Body).Decode(&body)
+
+	if err != nil || body.M == 0 || body.HashAlgorithm == "" {
+		log.Println("error when decoding migration body")
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode("Wrong body format")
+		return
+	}
+
+	log.Println("Migration request received. M: " + strconv.Itoa(body.M) + ", hash: " + body.HashAlgorithm)
+
+	if err := Migrate(s.rootNodeAddress, body.M, body.HashAlgorithm); err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (s *HttpServer) migrationStatus(w http.ResponseWriter, r *http.Request) {
+	response, err := MigrationStatus(s.rootNodeAddress)
+	if err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+// nodeState answers with the state of the root node, or of the node given
+// by the node query parameter.
+func (s *HttpServer) nodeState(w http.ResponseWriter, r *http.Request) {
+	address := r.URL.Query().Get("node")
+	if address == "" {
+		address = s.rootNodeAddress
+	}
+
+	response, err := NodeState(address)
+	if err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
+// stats answers with the counters of the api itself, the owner cache ones.
+func (s *HttpServer) stats(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(metrics.Snapshot())
+}
+
+func Query(address string, key string, iterative bool, consistency string) (*grpc_api.QueryResponse, error) {
 	conn, err := grpc.Dial(address, grpc.WithInsecure(), grpc.WithBlock())
 	if err != nil {
 		log.Fatalf("did not connect: %v", err)
@@ -140,7 +337,7 @@ func Query(address string, key int64) *grpc_api.QueryResponse {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*60)
 	defer cancel()
 
-	response, err := nc.Query(ctx, &grpc_api.QueryRequest{Key: key})
+	response, err := nc.Query(ctx, &grpc_api.QueryRequest{StrKey: key, Iterative: iterative, Consistency: consistency})
 
 	if err != nil {
 		log.Println(err.Error())
@@ -148,13 +345,13 @@ func Query(address string, key int64) *grpc_api.QueryResponse {
 			Data:                    nil,
 			ResponsibleNodeId:       0,
 			ResponsibleNodeEndpoint: "",
-		}
+		}, err
 	}
 
-	return response
+	return response, nil
 }
 
-func Save(address string, key int64, value []byte) *grpc_api.Empty {
+func Save(address string, key string, value []byte, consistency string) (*grpc_api.Empty, error) {
 	log.Println("connecting to the rpc server, rootNodeAddress:")
 	log.Println(address)
 	conn, err := grpc.Dial(address, grpc.WithInsecure(), grpc.WithBlock())
@@ -168,16 +365,16 @@ func Save(address string, key int64, value []byte) *grpc_api.Empty {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*60)
 	defer cancel()
 	log.Println("calling save rpc function")
-	_, err = nc.Save(ctx, &grpc_api.SaveRequest{Key: key, Data: value})
+	_, err = nc.Save(ctx, &grpc_api.SaveRequest{StrKey: key, Data: value, Consistency: consistency})
 
 	if err != nil {
 		log.Println(err.Error())
 	}
 
-	return &grpc_api.Empty{}
+	return &grpc_api.Empty{}, err
 }
 
-func Remove(address string, key int64) *grpc_api.Empty {
+func Remove(address string, key string, consistency string) (*grpc_api.Empty, error) {
 	conn, err := grpc.Dial(address, grpc.WithInsecure(), grpc.WithBlock())
 	if err != nil {
 		log.Fatalf("did not connect: %v", err)
@@ -189,11 +386,86 @@ func Remove(address string, key int64) *grpc_api.Empty {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*60)
 	defer cancel()
 
-	_, err = nc.Delete(ctx, &grpc_api.DeleteRequest{Key: key})
+	_, err = nc.Delete(ctx, &grpc_api.DeleteRequest{StrKey: key, Consistency: consistency})
 
 	if err != nil {
 		log.Println(err.Error())
 	}
 
-	return &grpc_api.Empty{}
+	return &grpc_api.Empty{}, err
+}
+
+func RangeQuery(address, namespace, start, end string, limit int) (*grpc_api.RangeQueryResponse, error) {
+	conn, err := grpc.Dial(address, grpc.WithInsecure(), grpc.WithBlock())
+	if err != nil {
+		log.Fatalf("did not connect: %v", err)
+	}
+
+	nc := grpc_api.NewDHTNodeClient(conn)
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*60)
+	defer cancel()
+
+	return nc.RangeQuery(ctx, &grpc_api.RangeQueryRequest{Namespace: namespace, Start: start, End: end, Limit: int32(limit)})
+}
+
+func clusterName() string {
+	if name := os.Getenv("CLUSTER_NAME"); name != "" {
+		return name
+	}
+	return "customdht"
+}
+
+func Migrate(address string, m int, hashAlgorithm string) error {
+	conn, err := grpc.Dial(address, grpc.WithInsecure(), grpc.WithBlock())
+	if err != nil {
+		log.Fatalf("did not connect: %v", err)
+	}
+
+	nc := grpc_api.NewDHTNodeClient(conn)
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*60)
+	defer cancel()
+	// the nodes only take a migration from callers naming their cluster
+	ctx = metadata.AppendToOutgoingContext(ctx, models.ClusterMetadataKey, clusterName())
+
+	_, err = nc.Migrate(ctx, &grpc_api.MigrateRequest{M: int32(m), HashAlgorithm: hashAlgorithm})
+	return err
+}
+
+func MigrationStatus(address string) (*grpc_api.MigrationStatusResponse, error) {
+	conn, err := grpc.Dial(address, grpc.WithInsecure(), grpc.WithBlock())
+	if err != nil {
+		log.Fatalf("did not connect: %v", err)
+	}
+
+	nc := grpc_api.NewDHTNodeClient(conn)
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*60)
+	defer cancel()
+
+	return nc.MigrationStatus(ctx, &grpc_api.MigrationStatusRequest{Cluster: true})
+}
+
+// NodeState asks the node at address, which comes from the caller and may
+// well be wrong, for its state. Failing to reach it is an error like any
+// other.
+func NodeState(address string) (*grpc_api.NodeStateResponse, error) {
+	dialCtx, dialCancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer dialCancel()
+	conn, err := grpc.DialContext(dialCtx, address, grpc.WithInsecure(), grpc.WithBlock())
+	if err != nil {
+		return nil, err
+	}
+
+	nc := grpc_api.NewDHTNodeClient(conn)
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	return nc.GetNodeState(ctx, &grpc_api.Empty{})
 }
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/raonismaneoto/CustomDHT/commons/grpc_api"
+	"github.com/raonismaneoto/CustomDHT/commons/helpers"
+	"github.com/raonismaneoto/CustomDHT/core/models"
+	"github.com/raonismaneoto/CustomDHT/core/ownercache"
+	"google.golang.org/grpc"
+)
+
+// ownerCache maps the ranges of the ring owned by the nodes to their
+// endpoint, as learned from the queries, so requests for any key in them skip
+// the lookup through the root node. The keys are placed on the ring with the
+// key space of the root node, asked for once and again after a cached owner
+// fails, in case the cluster migrated.
+type ownerCache struct {
+	mu       sync.Mutex
+	owners   *ownercache.Cache
+	keySpace *models.KeySpace
+	root     string
+}
+
+func newOwnerCache(root string) *ownerCache {
+	return &ownerCache{owners: ownercache.New(helpers.EnvInt("OWNER_CACHE_SIZE", 0, 1024)), root: root}
+}
+
+// position is the position of key on the ring, false while the key space
+// of the cluster is unknown or being migrated.
+func (c *ownerCache) position(key string) (int64, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.keySpace == nil {
+		status, err := localMigrationStatus(c.root)
+		if err != nil || !status.Complete {
+			return 0, false
+		}
+		c.keySpace = &models.KeySpace{M: int(status.M), Hash: status.HashAlgorithm}
+	}
+	return c.keySpace.Position(key), true
+}
+
+func (c *ownerCache) get(key string) (string, bool) {
+	if c.owners == nil {
+		return "", false
+	}
+	position, ok := c.position(key)
+	if !ok {
+		return "", false
+	}
+	owner, ok := c.owners.Get(position)
+	return owner.Address, ok
+}
+
+// put caches the range owned by the node answering response, when it told it.
+func (c *ownerCache) put(response *grpc_api.QueryResponse) {
+	if c.owners == nil || response == nil || !response.RangeKnown {
+		return
+	}
+	c.owners.Put(response.RangeStart, response.ResponsibleNodeId,
+		models.NodeRepresentation{Id: response.ResponsibleNodeId, Address: response.ResponsibleNodeEndpoint})
+}
+
+// invalidate drops every range cached as owned by endpoint.
+func (c *ownerCache) invalidate(endpoint string) {
+	c.owners.Invalidate(endpoint)
+	c.mu.Lock()
+	c.keySpace = nil
+	c.mu.Unlock()
+}
+
+// localMigrationStatus is the key space of the node at address alone, not
+// waiting on the rest of the ring.
+func localMigrationStatus(address string) (*grpc_api.MigrationStatusResponse, error) {
+	conn, err := dialOwner(address)
+	if err != nil {
+		return nil, err
+	}
+
+	nc := grpc_api.NewDHTNodeClient(conn)
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	return nc.MigrationStatus(ctx, &grpc_api.MigrationStatusRequest{})
+}
+
+// dialOwner does not block for long, a cached owner that is gone only costs
+// the fall back to the root node. The process of a virtual node answers for
+// all of its virtual nodes.
+func dialOwner(address string) (*grpc.ClientConn, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*2)
+	defer cancel()
+	return grpc.DialContext(ctx, models.PhysicalAddress(address), grpc.WithInsecure(), grpc.WithBlock())
+}
+
+// DirectQuery queries the node at address, which fails unless it owns key.
+func DirectQuery(address string, key string, consistency string) (*grpc_api.QueryResponse, error) {
+	conn, err := dialOwner(address)
+	if err != nil {
+		return nil, err
+	}
+
+	nc := grpc_api.NewDHTNodeClient(conn)
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	return nc.Query(ctx, &grpc_api.QueryRequest{StrKey: key, Direct: true, Consistency: consistency})
+}
+
+// DirectSave saves at the node at address, which fails unless it owns key.
+func DirectSave(address string, key string, value []byte, consistency string) error {
+	conn, err := dialOwner(address)
+	if err != nil {
+		return err
+	}
+
+	nc := grpc_api.NewDHTNodeClient(conn)
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	_, err = nc.Save(ctx, &grpc_api.SaveRequest{StrKey: key, Data: value, Direct: true, Consistency: consistency})
+	return err
+}
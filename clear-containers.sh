@@ -1,4 +1,4 @@
 #!/bin/bash
 
-sudo docker container stop $(sudo docker container ls -aq)
+sudo docker container stop -t 60 $(sudo docker container ls -aq)
 sudo docker container rm $(sudo docker container ls -aq)
\ No newline at end of file
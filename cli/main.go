@@ -0,0 +1,266 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/raonismaneoto/CustomDHT/commons/grpc_api"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const usage = `usage: cli <command> [flags]
+
+commands:
+  trace    print the path a lookup takes through the ring
+  stats    print the counters of a node
+  health   print whether a node has joined the ring
+  leave    hand the keys of a node off and take it out of the ring
+  check    walk the ring and check it is whole, exiting 1 when it is not
+  state    print the ring state of a node as json
+`
+
+// command line tool to inspect a running ring
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	var err error
+	switch os.Args[1] {
+	case "trace":
+		err = trace(os.Args[2:])
+	case "stats":
+		err = stats(os.Args[2:])
+	case "health":
+		err = health(os.Args[2:])
+	case "leave":
+		err = leave(os.Args[2:])
+	case "check":
+		err = check(os.Args[2:])
+	case "state":
+		err = state(os.Args[2:])
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err.Error())
+		os.Exit(1)
+	}
+}
+
+func trace(args []string) error {
+	flags := flag.NewFlagSet("trace", flag.ExitOnError)
+	address := flags.String("addr", os.Getenv("ROOT_NODE_ADDR"), "address of the node starting the lookup")
+	position := flags.Int64("position", 0, "trace a ring position instead of a key")
+	query := flags.Bool("query", false, "trace a query instead of an owner lookup")
+	iterative := flags.Bool("iterative", false, "drive the lookup iteratively from the starting node")
+	flags.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: cli trace [flags] <key>")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	key := flags.Arg(0)
+	if key == "" && *position == 0 {
+		flags.Usage()
+		os.Exit(2)
+	}
+
+	conn, err := grpc.Dial(*address, grpc.WithInsecure())
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	nc := grpc_api.NewDHTNodeClient(conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute*1)
+	defer cancel()
+
+	if *query {
+		response, err := nc.Query(ctx, &grpc_api.QueryRequest{Key: *position, StrKey: key, Iterative: *iterative, Trace: true})
+		if err != nil {
+			return err
+		}
+		printPath(response.Path)
+		fmt.Printf("owner %d %s, %d bytes\n", response.ResponsibleNodeId, response.ResponsibleNodeEndpoint, len(response.Data))
+		return nil
+	}
+
+	response, err := nc.Owner(ctx, &grpc_api.OwnerRequest{Key: *position, StrKey: key, Iterative: *iterative, Trace: true})
+	if err != nil {
+		return err
+	}
+	printPath(response.Path)
+	fmt.Printf("owner %d %s\n", response.OwnerNodeId, response.OwnerNodeEndpoint)
+	return nil
+}
+
+func stats(args []string) error {
+	flags := flag.NewFlagSet("stats", flag.ExitOnError)
+	address := flags.String("addr", os.Getenv("ROOT_NODE_ADDR"), "address of the node")
+	flags.Parse(args)
+
+	conn, err := grpc.Dial(*address, grpc.WithInsecure())
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	nc := grpc_api.NewDHTNodeClient(conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	response, err := nc.Stats(ctx, &grpc_api.Empty{})
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(response.Counters))
+	for name := range response.Counters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Printf("%-32s %d\n", name, response.Counters[name])
+	}
+	return nil
+}
+
+func health(args []string) error {
+	flags := flag.NewFlagSet("health", flag.ExitOnError)
+	address := flags.String("addr", os.Getenv("ROOT_NODE_ADDR"), "address of the node")
+	flags.Parse(args)
+
+	conn, err := grpc.Dial(*address, grpc.WithInsecure())
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	nc := grpc_api.NewDHTNodeClient(conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	response, err := nc.Health(ctx, &grpc_api.Empty{})
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%d %s %s\n", response.Id, response.Endpoint, response.Status)
+	if response.JoinAttempts > 0 {
+		fmt.Printf("join attempts %d, last seed %s\n", response.JoinAttempts, response.Seed)
+	}
+	if response.LastError != "" {
+		fmt.Println("last error: " + response.LastError)
+	}
+	return nil
+}
+
+func leave(args []string) error {
+	flags := flag.NewFlagSet("leave", flag.ExitOnError)
+	address := flags.String("addr", os.Getenv("ROOT_NODE_ADDR"), "address of the node leaving")
+	flags.Parse(args)
+
+	conn, err := grpc.Dial(*address, grpc.WithInsecure())
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	nc := grpc_api.NewDHTNodeClient(conn)
+
+	// every key of the node is streamed out before the call returns
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute*10)
+	defer cancel()
+
+	if _, err := nc.Leave(ctx, &grpc_api.Empty{}); err != nil {
+		return err
+	}
+	fmt.Println(*address + " left the ring")
+	return nil
+}
+
+func check(args []string) error {
+	flags := flag.NewFlagSet("check", flag.ExitOnError)
+	address := flags.String("addr", os.Getenv("ROOT_NODE_ADDR"), "address of the node the walk starts from")
+	verbose := flags.Bool("v", false, "list the nodes of the ring")
+	flags.Parse(args)
+
+	conn, err := grpc.Dial(*address, grpc.WithInsecure())
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	nc := grpc_api.NewDHTNodeClient(conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute*5)
+	defer cancel()
+
+	response, err := nc.CheckRing(ctx, &grpc_api.Empty{})
+	if err != nil {
+		return err
+	}
+	if *verbose {
+		for _, node := range response.Ring {
+			fmt.Printf("%-12d %s\n", node.Id, node.Endpoint)
+		}
+	}
+	for _, problem := range response.Problems {
+		fmt.Println(problem)
+	}
+
+	result := "OK"
+	if !response.Consistent {
+		result = "FAIL"
+	}
+	fmt.Printf("nodes=%d bad_predecessors=%d bad_order=%d bad_fingers=%d orphans=%d split_rings=%d unreachable=%d %s\n",
+		len(response.Ring), response.BadPredecessors, response.BadOrder, response.BadFingers,
+		response.Orphans, response.SplitRings, response.Unreachable, result)
+	if !response.Consistent {
+		os.Exit(1)
+	}
+	return nil
+}
+
+func state(args []string) error {
+	flags := flag.NewFlagSet("state", flag.ExitOnError)
+	address := flags.String("addr", os.Getenv("ROOT_NODE_ADDR"), "address of the node")
+	flags.Parse(args)
+
+	conn, err := grpc.Dial(*address, grpc.WithInsecure())
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	nc := grpc_api.NewDHTNodeClient(conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	response, err := nc.GetNodeState(ctx, &grpc_api.Empty{})
+	if err != nil {
+		return err
+	}
+	content, err := protojson.MarshalOptions{Multiline: true, EmitUnpopulated: true}.Marshal(response)
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(content))
+	return nil
+}
+
+func printPath(path []*grpc_api.Hop) {
+	for i, hop := range path {
+		line := fmt.Sprintf("%3d  %-12d %-28s %v", i, hop.Id, hop.Endpoint, time.Duration(hop.ElapsedMicros)*time.Microsecond)
+		if hop.Error != "" {
+			line += "  error: " + hop.Error
+		}
+		fmt.Println(line)
+	}
+}
@@ -24,9 +24,16 @@ const _ = grpc.SupportPackageIsVersion7
 type DHTNodeClient interface {
 	Ping(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error)
 	Successor(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*SuccessorResponse, error)
+	SuccessorList(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*SuccessorListResponse, error)
 	Predecessor(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*PredecessorResponse, error)
 	HandleNewPredecessor(ctx context.Context, in *HandleNewPredecessorRequest, opts ...grpc.CallOption) (*HandleNewPredecessorResponse, error)
 	HandleNewSuccessor(ctx context.Context, in *HandleNewSuccessorRequest, opts ...grpc.CallOption) (*HandleNewSuccessorResponse, error)
+	Notify(ctx context.Context, in *NotifyRequest, opts ...grpc.CallOption) (*NotifyResponse, error)
+	Probe(ctx context.Context, in *ProbeRequest, opts ...grpc.CallOption) (*ProbeResponse, error)
+	Leave(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error)
+	HandleLeave(ctx context.Context, in *LeaveNotice, opts ...grpc.CallOption) (*Empty, error)
+	Merge(ctx context.Context, in *MergeRequest, opts ...grpc.CallOption) (*Empty, error)
+	Handoff(ctx context.Context, opts ...grpc.CallOption) (DHTNode_HandoffClient, error)
 	Query(ctx context.Context, in *QueryRequest, opts ...grpc.CallOption) (*QueryResponse, error)
 	Save(ctx context.Context, in *SaveRequest, opts ...grpc.CallOption) (*Empty, error)
 	Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*Empty, error)
@@ -34,6 +41,19 @@ type DHTNodeClient interface {
 	SaveStream(ctx context.Context, opts ...grpc.CallOption) (DHTNode_SaveStreamClient, error)
 	QueryStream(ctx context.Context, in *QueryRequest, opts ...grpc.CallOption) (DHTNode_QueryStreamClient, error)
 	Owner(ctx context.Context, in *OwnerRequest, opts ...grpc.CallOption) (*OwnerResponse, error)
+	ClosestPreceding(ctx context.Context, in *ClosestPrecedingRequest, opts ...grpc.CallOption) (*ClosestPrecedingResponse, error)
+	FindNode(ctx context.Context, in *FindNodeRequest, opts ...grpc.CallOption) (*FindNodeResponse, error)
+	RangeQuery(ctx context.Context, in *RangeQueryRequest, opts ...grpc.CallOption) (*RangeQueryResponse, error)
+	Load(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*LoadResponse, error)
+	Keys(ctx context.Context, in *KeysRequest, opts ...grpc.CallOption) (*KeysResponse, error)
+	Migrate(ctx context.Context, in *MigrateRequest, opts ...grpc.CallOption) (*Empty, error)
+	MigrationStatus(ctx context.Context, in *MigrationStatusRequest, opts ...grpc.CallOption) (*MigrationStatusResponse, error)
+	Stats(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*StatsResponse, error)
+	Health(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*HealthResponse, error)
+	Fingers(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*FingersResponse, error)
+	CheckRing(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*CheckRingResponse, error)
+	GetNodeState(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*NodeStateResponse, error)
+	Handshake(ctx context.Context, in *ClusterIdentity, opts ...grpc.CallOption) (*ClusterIdentity, error)
 }
 
 type dHTNodeClient struct {
@@ -62,6 +82,15 @@ func (c *dHTNodeClient) Successor(ctx context.Context, in *Empty, opts ...grpc.C
 	return out, nil
 }
 
+func (c *dHTNodeClient) SuccessorList(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*SuccessorListResponse, error) {
+	out := new(SuccessorListResponse)
+	err := c.cc.Invoke(ctx, "/grpc_api.DHTNode/SuccessorList", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *dHTNodeClient) Predecessor(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*PredecessorResponse, error) {
 	out := new(PredecessorResponse)
 	err := c.cc.Invoke(ctx, "/grpc_api.DHTNode/Predecessor", in, out, opts...)
@@ -89,6 +118,85 @@ func (c *dHTNodeClient) HandleNewSuccessor(ctx context.Context, in *HandleNewSuc
 	return out, nil
 }
 
+func (c *dHTNodeClient) Notify(ctx context.Context, in *NotifyRequest, opts ...grpc.CallOption) (*NotifyResponse, error) {
+	out := new(NotifyResponse)
+	err := c.cc.Invoke(ctx, "/grpc_api.DHTNode/Notify", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dHTNodeClient) Probe(ctx context.Context, in *ProbeRequest, opts ...grpc.CallOption) (*ProbeResponse, error) {
+	out := new(ProbeResponse)
+	err := c.cc.Invoke(ctx, "/grpc_api.DHTNode/Probe", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dHTNodeClient) Leave(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/grpc_api.DHTNode/Leave", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dHTNodeClient) HandleLeave(ctx context.Context, in *LeaveNotice, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/grpc_api.DHTNode/HandleLeave", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dHTNodeClient) Merge(ctx context.Context, in *MergeRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/grpc_api.DHTNode/Merge", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dHTNodeClient) Handoff(ctx context.Context, opts ...grpc.CallOption) (DHTNode_HandoffClient, error) {
+	stream, err := c.cc.NewStream(ctx, &DHTNode_ServiceDesc.Streams[0], "/grpc_api.DHTNode/Handoff", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &dHTNodeHandoffClient{stream}
+	return x, nil
+}
+
+type DHTNode_HandoffClient interface {
+	Send(*HandoffEntry) error
+	CloseAndRecv() (*Empty, error)
+	grpc.ClientStream
+}
+
+type dHTNodeHandoffClient struct {
+	grpc.ClientStream
+}
+
+func (x *dHTNodeHandoffClient) Send(m *HandoffEntry) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *dHTNodeHandoffClient) CloseAndRecv() (*Empty, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(Empty)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *dHTNodeClient) Query(ctx context.Context, in *QueryRequest, opts ...grpc.CallOption) (*QueryResponse, error) {
 	out := new(QueryResponse)
 	err := c.cc.Invoke(ctx, "/grpc_api.DHTNode/Query", in, out, opts...)
@@ -126,7 +234,7 @@ func (c *dHTNodeClient) RepSave(ctx context.Context, in *RepSaveRequest, opts ..
 }
 
 func (c *dHTNodeClient) SaveStream(ctx context.Context, opts ...grpc.CallOption) (DHTNode_SaveStreamClient, error) {
-	stream, err := c.cc.NewStream(ctx, &DHTNode_ServiceDesc.Streams[0], "/grpc_api.DHTNode/SaveStream", opts...)
+	stream, err := c.cc.NewStream(ctx, &DHTNode_ServiceDesc.Streams[1], "/grpc_api.DHTNode/SaveStream", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -160,7 +268,7 @@ func (x *dHTNodeSaveStreamClient) CloseAndRecv() (*Empty, error) {
 }
 
 func (c *dHTNodeClient) QueryStream(ctx context.Context, in *QueryRequest, opts ...grpc.CallOption) (DHTNode_QueryStreamClient, error) {
-	stream, err := c.cc.NewStream(ctx, &DHTNode_ServiceDesc.Streams[1], "/grpc_api.DHTNode/QueryStream", opts...)
+	stream, err := c.cc.NewStream(ctx, &DHTNode_ServiceDesc.Streams[2], "/grpc_api.DHTNode/QueryStream", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -200,15 +308,139 @@ func (c *dHTNodeClient) Owner(ctx context.Context, in *OwnerRequest, opts ...grp
 	return out, nil
 }
 
+func (c *dHTNodeClient) ClosestPreceding(ctx context.Context, in *ClosestPrecedingRequest, opts ...grpc.CallOption) (*ClosestPrecedingResponse, error) {
+	out := new(ClosestPrecedingResponse)
+	err := c.cc.Invoke(ctx, "/grpc_api.DHTNode/ClosestPreceding", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dHTNodeClient) FindNode(ctx context.Context, in *FindNodeRequest, opts ...grpc.CallOption) (*FindNodeResponse, error) {
+	out := new(FindNodeResponse)
+	err := c.cc.Invoke(ctx, "/grpc_api.DHTNode/FindNode", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dHTNodeClient) RangeQuery(ctx context.Context, in *RangeQueryRequest, opts ...grpc.CallOption) (*RangeQueryResponse, error) {
+	out := new(RangeQueryResponse)
+	err := c.cc.Invoke(ctx, "/grpc_api.DHTNode/RangeQuery", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dHTNodeClient) Load(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*LoadResponse, error) {
+	out := new(LoadResponse)
+	err := c.cc.Invoke(ctx, "/grpc_api.DHTNode/Load", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dHTNodeClient) Keys(ctx context.Context, in *KeysRequest, opts ...grpc.CallOption) (*KeysResponse, error) {
+	out := new(KeysResponse)
+	err := c.cc.Invoke(ctx, "/grpc_api.DHTNode/Keys", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dHTNodeClient) Migrate(ctx context.Context, in *MigrateRequest, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/grpc_api.DHTNode/Migrate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dHTNodeClient) MigrationStatus(ctx context.Context, in *MigrationStatusRequest, opts ...grpc.CallOption) (*MigrationStatusResponse, error) {
+	out := new(MigrationStatusResponse)
+	err := c.cc.Invoke(ctx, "/grpc_api.DHTNode/MigrationStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dHTNodeClient) Stats(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*StatsResponse, error) {
+	out := new(StatsResponse)
+	err := c.cc.Invoke(ctx, "/grpc_api.DHTNode/Stats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dHTNodeClient) Health(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*HealthResponse, error) {
+	out := new(HealthResponse)
+	err := c.cc.Invoke(ctx, "/grpc_api.DHTNode/Health", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dHTNodeClient) Fingers(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*FingersResponse, error) {
+	out := new(FingersResponse)
+	err := c.cc.Invoke(ctx, "/grpc_api.DHTNode/Fingers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dHTNodeClient) CheckRing(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*CheckRingResponse, error) {
+	out := new(CheckRingResponse)
+	err := c.cc.Invoke(ctx, "/grpc_api.DHTNode/CheckRing", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dHTNodeClient) GetNodeState(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*NodeStateResponse, error) {
+	out := new(NodeStateResponse)
+	err := c.cc.Invoke(ctx, "/grpc_api.DHTNode/GetNodeState", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dHTNodeClient) Handshake(ctx context.Context, in *ClusterIdentity, opts ...grpc.CallOption) (*ClusterIdentity, error) {
+	out := new(ClusterIdentity)
+	err := c.cc.Invoke(ctx, "/grpc_api.DHTNode/Handshake", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DHTNodeServer is the server API for DHTNode service.
 // All implementations should embed UnimplementedDHTNodeServer
 // for forward compatibility
 type DHTNodeServer interface {
 	Ping(context.Context, *Empty) (*Empty, error)
 	Successor(context.Context, *Empty) (*SuccessorResponse, error)
+	SuccessorList(context.Context, *Empty) (*SuccessorListResponse, error)
 	Predecessor(context.Context, *Empty) (*PredecessorResponse, error)
 	HandleNewPredecessor(context.Context, *HandleNewPredecessorRequest) (*HandleNewPredecessorResponse, error)
 	HandleNewSuccessor(context.Context, *HandleNewSuccessorRequest) (*HandleNewSuccessorResponse, error)
+	Notify(context.Context, *NotifyRequest) (*NotifyResponse, error)
+	Probe(context.Context, *ProbeRequest) (*ProbeResponse, error)
+	Leave(context.Context, *Empty) (*Empty, error)
+	HandleLeave(context.Context, *LeaveNotice) (*Empty, error)
+	Merge(context.Context, *MergeRequest) (*Empty, error)
+	Handoff(DHTNode_HandoffServer) error
 	Query(context.Context, *QueryRequest) (*QueryResponse, error)
 	Save(context.Context, *SaveRequest) (*Empty, error)
 	Delete(context.Context, *DeleteRequest) (*Empty, error)
@@ -216,6 +448,19 @@ type DHTNodeServer interface {
 	SaveStream(DHTNode_SaveStreamServer) error
 	QueryStream(*QueryRequest, DHTNode_QueryStreamServer) error
 	Owner(context.Context, *OwnerRequest) (*OwnerResponse, error)
+	ClosestPreceding(context.Context, *ClosestPrecedingRequest) (*ClosestPrecedingResponse, error)
+	FindNode(context.Context, *FindNodeRequest) (*FindNodeResponse, error)
+	RangeQuery(context.Context, *RangeQueryRequest) (*RangeQueryResponse, error)
+	Load(context.Context, *Empty) (*LoadResponse, error)
+	Keys(context.Context, *KeysRequest) (*KeysResponse, error)
+	Migrate(context.Context, *MigrateRequest) (*Empty, error)
+	MigrationStatus(context.Context, *MigrationStatusRequest) (*MigrationStatusResponse, error)
+	Stats(context.Context, *Empty) (*StatsResponse, error)
+	Health(context.Context, *Empty) (*HealthResponse, error)
+	Fingers(context.Context, *Empty) (*FingersResponse, error)
+	CheckRing(context.Context, *Empty) (*CheckRingResponse, error)
+	GetNodeState(context.Context, *Empty) (*NodeStateResponse, error)
+	Handshake(context.Context, *ClusterIdentity) (*ClusterIdentity, error)
 }
 
 // UnimplementedDHTNodeServer should be embedded to have forward compatible implementations.
@@ -228,6 +473,9 @@ func (UnimplementedDHTNodeServer) Ping(context.Context, *Empty) (*Empty, error)
 func (UnimplementedDHTNodeServer) Successor(context.Context, *Empty) (*SuccessorResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Successor not implemented")
 }
+func (UnimplementedDHTNodeServer) SuccessorList(context.Context, *Empty) (*SuccessorListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SuccessorList not implemented")
+}
 func (UnimplementedDHTNodeServer) Predecessor(context.Context, *Empty) (*PredecessorResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Predecessor not implemented")
 }
@@ -237,6 +485,24 @@ func (UnimplementedDHTNodeServer) HandleNewPredecessor(context.Context, *HandleN
 func (UnimplementedDHTNodeServer) HandleNewSuccessor(context.Context, *HandleNewSuccessorRequest) (*HandleNewSuccessorResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method HandleNewSuccessor not implemented")
 }
+func (UnimplementedDHTNodeServer) Notify(context.Context, *NotifyRequest) (*NotifyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Notify not implemented")
+}
+func (UnimplementedDHTNodeServer) Probe(context.Context, *ProbeRequest) (*ProbeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Probe not implemented")
+}
+func (UnimplementedDHTNodeServer) Leave(context.Context, *Empty) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Leave not implemented")
+}
+func (UnimplementedDHTNodeServer) HandleLeave(context.Context, *LeaveNotice) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method HandleLeave not implemented")
+}
+func (UnimplementedDHTNodeServer) Merge(context.Context, *MergeRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Merge not implemented")
+}
+func (UnimplementedDHTNodeServer) Handoff(DHTNode_HandoffServer) error {
+	return status.Errorf(codes.Unimplemented, "method Handoff not implemented")
+}
 func (UnimplementedDHTNodeServer) Query(context.Context, *QueryRequest) (*QueryResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Query not implemented")
 }
@@ -258,6 +524,45 @@ func (UnimplementedDHTNodeServer) QueryStream(*QueryRequest, DHTNode_QueryStream
 func (UnimplementedDHTNodeServer) Owner(context.Context, *OwnerRequest) (*OwnerResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Owner not implemented")
 }
+func (UnimplementedDHTNodeServer) ClosestPreceding(context.Context, *ClosestPrecedingRequest) (*ClosestPrecedingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ClosestPreceding not implemented")
+}
+func (UnimplementedDHTNodeServer) FindNode(context.Context, *FindNodeRequest) (*FindNodeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindNode not implemented")
+}
+func (UnimplementedDHTNodeServer) RangeQuery(context.Context, *RangeQueryRequest) (*RangeQueryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RangeQuery not implemented")
+}
+func (UnimplementedDHTNodeServer) Load(context.Context, *Empty) (*LoadResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Load not implemented")
+}
+func (UnimplementedDHTNodeServer) Keys(context.Context, *KeysRequest) (*KeysResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Keys not implemented")
+}
+func (UnimplementedDHTNodeServer) Migrate(context.Context, *MigrateRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Migrate not implemented")
+}
+func (UnimplementedDHTNodeServer) MigrationStatus(context.Context, *MigrationStatusRequest) (*MigrationStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MigrationStatus not implemented")
+}
+func (UnimplementedDHTNodeServer) Stats(context.Context, *Empty) (*StatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Stats not implemented")
+}
+func (UnimplementedDHTNodeServer) Health(context.Context, *Empty) (*HealthResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Health not implemented")
+}
+func (UnimplementedDHTNodeServer) Fingers(context.Context, *Empty) (*FingersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Fingers not implemented")
+}
+func (UnimplementedDHTNodeServer) CheckRing(context.Context, *Empty) (*CheckRingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckRing not implemented")
+}
+func (UnimplementedDHTNodeServer) GetNodeState(context.Context, *Empty) (*NodeStateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNodeState not implemented")
+}
+func (UnimplementedDHTNodeServer) Handshake(context.Context, *ClusterIdentity) (*ClusterIdentity, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Handshake not implemented")
+}
 
 // UnsafeDHTNodeServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to DHTNodeServer will
@@ -306,6 +611,24 @@ func _DHTNode_Successor_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DHTNode_SuccessorList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DHTNodeServer).SuccessorList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc_api.DHTNode/SuccessorList",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DHTNodeServer).SuccessorList(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _DHTNode_Predecessor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(Empty)
 	if err := dec(in); err != nil {
@@ -360,6 +683,122 @@ func _DHTNode_HandleNewSuccessor_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DHTNode_Notify_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NotifyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DHTNodeServer).Notify(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc_api.DHTNode/Notify",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DHTNodeServer).Notify(ctx, req.(*NotifyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DHTNode_Probe_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProbeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DHTNodeServer).Probe(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc_api.DHTNode/Probe",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DHTNodeServer).Probe(ctx, req.(*ProbeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DHTNode_Leave_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DHTNodeServer).Leave(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc_api.DHTNode/Leave",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DHTNodeServer).Leave(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DHTNode_HandleLeave_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LeaveNotice)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DHTNodeServer).HandleLeave(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc_api.DHTNode/HandleLeave",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DHTNodeServer).HandleLeave(ctx, req.(*LeaveNotice))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DHTNode_Merge_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MergeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DHTNodeServer).Merge(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc_api.DHTNode/Merge",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DHTNodeServer).Merge(ctx, req.(*MergeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DHTNode_Handoff_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(DHTNodeServer).Handoff(&dHTNodeHandoffServer{stream})
+}
+
+type DHTNode_HandoffServer interface {
+	SendAndClose(*Empty) error
+	Recv() (*HandoffEntry, error)
+	grpc.ServerStream
+}
+
+type dHTNodeHandoffServer struct {
+	grpc.ServerStream
+}
+
+func (x *dHTNodeHandoffServer) SendAndClose(m *Empty) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *dHTNodeHandoffServer) Recv() (*HandoffEntry, error) {
+	m := new(HandoffEntry)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func _DHTNode_Query_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(QueryRequest)
 	if err := dec(in); err != nil {
@@ -497,6 +936,240 @@ func _DHTNode_Owner_Handler(srv interface{}, ctx context.Context, dec func(inter
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DHTNode_ClosestPreceding_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClosestPrecedingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DHTNodeServer).ClosestPreceding(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc_api.DHTNode/ClosestPreceding",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DHTNodeServer).ClosestPreceding(ctx, req.(*ClosestPrecedingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DHTNode_FindNode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindNodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DHTNodeServer).FindNode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc_api.DHTNode/FindNode",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DHTNodeServer).FindNode(ctx, req.(*FindNodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DHTNode_RangeQuery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RangeQueryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DHTNodeServer).RangeQuery(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc_api.DHTNode/RangeQuery",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DHTNodeServer).RangeQuery(ctx, req.(*RangeQueryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DHTNode_Load_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DHTNodeServer).Load(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc_api.DHTNode/Load",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DHTNodeServer).Load(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DHTNode_Keys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(KeysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DHTNodeServer).Keys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc_api.DHTNode/Keys",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DHTNodeServer).Keys(ctx, req.(*KeysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DHTNode_Migrate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MigrateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DHTNodeServer).Migrate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc_api.DHTNode/Migrate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DHTNodeServer).Migrate(ctx, req.(*MigrateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DHTNode_MigrationStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MigrationStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DHTNodeServer).MigrationStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc_api.DHTNode/MigrationStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DHTNodeServer).MigrationStatus(ctx, req.(*MigrationStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DHTNode_Stats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DHTNodeServer).Stats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc_api.DHTNode/Stats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DHTNodeServer).Stats(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DHTNode_Health_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DHTNodeServer).Health(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc_api.DHTNode/Health",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DHTNodeServer).Health(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DHTNode_Fingers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DHTNodeServer).Fingers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc_api.DHTNode/Fingers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DHTNodeServer).Fingers(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DHTNode_CheckRing_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DHTNodeServer).CheckRing(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc_api.DHTNode/CheckRing",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DHTNodeServer).CheckRing(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DHTNode_GetNodeState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DHTNodeServer).GetNodeState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc_api.DHTNode/GetNodeState",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DHTNodeServer).GetNodeState(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DHTNode_Handshake_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClusterIdentity)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DHTNodeServer).Handshake(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc_api.DHTNode/Handshake",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DHTNodeServer).Handshake(ctx, req.(*ClusterIdentity))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // DHTNode_ServiceDesc is the grpc.ServiceDesc for DHTNode service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -512,6 +1185,10 @@ var DHTNode_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Successor",
 			Handler:    _DHTNode_Successor_Handler,
 		},
+		{
+			MethodName: "SuccessorList",
+			Handler:    _DHTNode_SuccessorList_Handler,
+		},
 		{
 			MethodName: "Predecessor",
 			Handler:    _DHTNode_Predecessor_Handler,
@@ -524,6 +1201,26 @@ var DHTNode_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "HandleNewSuccessor",
 			Handler:    _DHTNode_HandleNewSuccessor_Handler,
 		},
+		{
+			MethodName: "Notify",
+			Handler:    _DHTNode_Notify_Handler,
+		},
+		{
+			MethodName: "Probe",
+			Handler:    _DHTNode_Probe_Handler,
+		},
+		{
+			MethodName: "Leave",
+			Handler:    _DHTNode_Leave_Handler,
+		},
+		{
+			MethodName: "HandleLeave",
+			Handler:    _DHTNode_HandleLeave_Handler,
+		},
+		{
+			MethodName: "Merge",
+			Handler:    _DHTNode_Merge_Handler,
+		},
 		{
 			MethodName: "Query",
 			Handler:    _DHTNode_Query_Handler,
@@ -544,8 +1241,65 @@ var DHTNode_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Owner",
 			Handler:    _DHTNode_Owner_Handler,
 		},
+		{
+			MethodName: "ClosestPreceding",
+			Handler:    _DHTNode_ClosestPreceding_Handler,
+		},
+		{
+			MethodName: "FindNode",
+			Handler:    _DHTNode_FindNode_Handler,
+		},
+		{
+			MethodName: "RangeQuery",
+			Handler:    _DHTNode_RangeQuery_Handler,
+		},
+		{
+			MethodName: "Load",
+			Handler:    _DHTNode_Load_Handler,
+		},
+		{
+			MethodName: "Keys",
+			Handler:    _DHTNode_Keys_Handler,
+		},
+		{
+			MethodName: "Migrate",
+			Handler:    _DHTNode_Migrate_Handler,
+		},
+		{
+			MethodName: "MigrationStatus",
+			Handler:    _DHTNode_MigrationStatus_Handler,
+		},
+		{
+			MethodName: "Stats",
+			Handler:    _DHTNode_Stats_Handler,
+		},
+		{
+			MethodName: "Health",
+			Handler:    _DHTNode_Health_Handler,
+		},
+		{
+			MethodName: "Fingers",
+			Handler:    _DHTNode_Fingers_Handler,
+		},
+		{
+			MethodName: "CheckRing",
+			Handler:    _DHTNode_CheckRing_Handler,
+		},
+		{
+			MethodName: "GetNodeState",
+			Handler:    _DHTNode_GetNodeState_Handler,
+		},
+		{
+			MethodName: "Handshake",
+			Handler:    _DHTNode_Handshake_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Handoff",
+			Handler:       _DHTNode_Handoff_Handler,
+			ClientStreams: true,
+		},
 		{
 			StreamName:    "SaveStream",
 			Handler:       _DHTNode_SaveStream_Handler,
@@ -2,15 +2,44 @@ package helpers
 
 import (
 	"crypto/sha1"
+	"encoding/binary"
 	"fmt"
+	"io/ioutil"
 	"log"
 	"math"
 	"net"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 )
 
+const (
+	LegacyHash = "sha1-sum"
+	Sha1Hash   = "sha1"
+)
+
+func IsHashAlgorithmSupported(algorithm string) bool {
+	return algorithm == LegacyHash || algorithm == Sha1Hash
+}
+
+func GetHashWith(key string, m int, algorithm string) int64 {
+	if algorithm != Sha1Hash {
+		return GetHash(key, m)
+	}
+	hash := sha1.Sum([]byte(key))
+	return int64(binary.BigEndian.Uint64(hash[:8]) >> uint(64-m))
+}
+
+// ScalePosition maps a ring position to a ring of a different size keeping
+// its relative place, so ring order survives a change of M.
+func ScalePosition(position int64, fromM int, toM int) int64 {
+	if toM >= fromM {
+		return position << uint(toM-fromM)
+	}
+	return position >> uint(fromM-toM)
+}
+
 func GetHash(key string, m int) int64 {
 	h := sha1.New()
 	h.Write([]byte(key))
@@ -27,6 +56,72 @@ func GetHash(key string, m int) int64 {
 	return sum % int64(math.Pow(2.0, float64(m)))
 }
 
+// GetOrderedHash maps key to a ring position keeping the lexical order of
+// the keys, by reading its leading bytes as a fraction of the ring.
+func GetOrderedHash(key string, m int) int64 {
+	var prefix [8]byte
+	copy(prefix[:], key)
+	return int64(binary.BigEndian.Uint64(prefix[:]) >> uint(64-m))
+}
+
+func OrderedNamespaces() []string {
+	var namespaces []string
+	for _, namespace := range strings.Split(os.Getenv("ORDERED_NAMESPACES"), ",") {
+		if namespace = strings.TrimSpace(namespace); namespace != "" {
+			namespaces = append(namespaces, namespace)
+		}
+	}
+	return namespaces
+}
+
+// SplitOrderedKey tells whether key belongs to an order preserving namespace
+// and returns the part of the key placed on the ring.
+func SplitOrderedKey(key string) (string, string, bool) {
+	parts := strings.SplitN(key, ":", 2)
+	if len(parts) < 2 {
+		return "", "", false
+	}
+	for _, namespace := range OrderedNamespaces() {
+		if namespace == parts[0] {
+			return parts[0], parts[1], true
+		}
+	}
+	return "", "", false
+}
+
+func IsOrderedKey(key string) bool {
+	_, _, ok := SplitOrderedKey(key)
+	return ok
+}
+
+func GetKeyPosition(key string, m int, algorithm string) int64 {
+	if _, rest, ok := SplitOrderedKey(key); ok {
+		return GetOrderedHash(rest, m)
+	}
+	return GetHashWith(key, m, algorithm)
+}
+
+// GetSaltedHash is GetHashWith for the salt-th id tried for key, 0 being
+// the unsalted one.
+func GetSaltedHash(key string, salt int, m int, algorithm string) int64 {
+	if salt == 0 {
+		return GetHashWith(key, m, algorithm)
+	}
+	return GetHashWith(key+"#"+strconv.Itoa(salt), m, algorithm)
+}
+
+func ReadIdFile(path string) (int64, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(strings.TrimSpace(string(content)), 10, 64)
+}
+
+func WriteIdFile(path string, id int64) error {
+	return ioutil.WriteFile(path, []byte(strconv.FormatInt(id, 10)), 0644)
+}
+
 func SetupLogging(id int64) {
 	file, err := os.Create("logs-node-" + strconv.FormatInt(id, 10) + ".txt")
 	if err != nil {
@@ -47,6 +142,26 @@ func GetOutboundIP() string {
 	return localAddr.IP.String()
 }
 
+// DataDir is the directory a node keeps its files in, DATA_DIR, the working
+// directory when unset.
+func DataDir() string {
+	dir := os.Getenv("DATA_DIR")
+	if dir == "" {
+		return "."
+	}
+	return dir
+}
+
+// EnvInt reads the integer in the environment variable name, fallback
+// standing for it when it is unset, not a number or below min.
+func EnvInt(name string, min int, fallback int) int {
+	value, err := strconv.Atoi(os.Getenv(name))
+	if err != nil || value < min {
+		return fallback
+	}
+	return value
+}
+
 func PeriodicInvocation(f func(), secs int) {
 	go func() {
 		ticker := time.NewTicker(time.Duration(secs) * time.Second)
@@ -4,16 +4,22 @@ import (
 	"context"
 	"io"
 	"log"
+	"sync"
 	"time"
 
 	"github.com/cenkalti/backoff"
 	"github.com/raonismaneoto/CustomDHT/commons/grpc_api"
 	"github.com/raonismaneoto/CustomDHT/core/models"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 type Client struct {
 	connections map[string]*grpc.ClientConn
+	mu          sync.Mutex
+	identity    func() models.ClusterIdentity
 }
 
 func New() *Client {
@@ -22,6 +28,23 @@ func New() *Client {
 	return c
 }
 
+// SetIdentity has every call carry the cluster identity identity gives, the
+// nodes called turning membership calls of other clusters down.
+func (c *Client) SetIdentity(identity func() models.ClusterIdentity) {
+	c.identity = identity
+}
+
+// Handshake tells the node at address the identity of this one, getting its
+// own back. It fails when they are not of the same cluster.
+func (c *Client) Handshake(address string, identity models.ClusterIdentity) (*grpc_api.ClusterIdentity, error) {
+	nc := c.getClient(address)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	return nc.Handshake(ctx, &grpc_api.ClusterIdentity{Name: identity.Name, KeySpaces: identity.KeySpaceStrings()})
+}
+
 func (c *Client) Ping(address string) (*grpc_api.Empty, error) {
 	nc := c.getClient(address)
 
@@ -50,7 +73,7 @@ func (c *Client) HandleNewSuccessor(receiverAddress string, newSucc models.NodeR
 
 	retryable := func() error {
 		response, err = nc.HandleNewSuccessor(ctx, &grpc_api.HandleNewSuccessorRequest{Endpoint: newSucc.Address, Id: newSucc.Id, NSuccEndpoint: nNSucc.Address, NSuccId: nNSucc.Id})
-		return err
+		return nonRetryable(err)
 	}
 
 	b := backoff.NewExponentialBackOff()
@@ -59,7 +82,7 @@ func (c *Client) HandleNewSuccessor(receiverAddress string, newSucc models.NodeR
 	backoff.Retry(retryable, b)
 
 	if err != nil {
-		log.Fatalf("error after retrying: %v", err)
+		log.Printf("error after retrying: %v", err)
 		return nil, err
 	}
 
@@ -79,7 +102,7 @@ func (c *Client) HandleNewPredecessor(receiverAddress string, newPred models.Nod
 
 	retryable := func() error {
 		response, err = nc.HandleNewPredecessor(ctx, &grpc_api.HandleNewPredecessorRequest{Endpoint: newPred.Address, Id: newPred.Id})
-		return err
+		return nonRetryable(err)
 	}
 
 	b := backoff.NewExponentialBackOff()
@@ -94,6 +117,81 @@ func (c *Client) HandleNewPredecessor(receiverAddress string, newPred models.Nod
 	return response, nil
 }
 
+func (c *Client) Notify(address string, candidate models.NodeRepresentation) (*grpc_api.NotifyResponse, error) {
+	nc := c.getClient(address)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	return nc.Notify(ctx, &grpc_api.NotifyRequest{Id: candidate.Id, Endpoint: candidate.Address})
+}
+
+func (c *Client) Merge(address string, candidate models.NodeRepresentation, hops int32) (*grpc_api.Empty, error) {
+	nc := c.getClient(address)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	return nc.Merge(ctx, &grpc_api.MergeRequest{Candidate: &grpc_api.NodeInfo{Id: candidate.Id, Endpoint: candidate.Address}, Hops: hops})
+}
+
+func (c *Client) HandleLeave(address string, leaving, predecessor, successor models.NodeRepresentation) (*grpc_api.Empty, error) {
+	nc := c.getClient(address)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	var (
+		response *grpc_api.Empty
+		err      error
+	)
+
+	retryable := func() error {
+		response, err = nc.HandleLeave(ctx, &grpc_api.LeaveNotice{
+			Leaving:     &grpc_api.NodeInfo{Id: leaving.Id, Endpoint: leaving.Address},
+			Predecessor: &grpc_api.NodeInfo{Id: predecessor.Id, Endpoint: predecessor.Address},
+			Successor:   &grpc_api.NodeInfo{Id: successor.Id, Endpoint: successor.Address},
+		})
+		return nonRetryable(err)
+	}
+
+	b := backoff.NewExponentialBackOff()
+	b.MaxElapsedTime = time.Second * 10
+
+	backoff.Retry(retryable, b)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// Handoff streams entries to address, failing on the first one not sent.
+func (c *Client) Handoff(address string, entries []*grpc_api.HandoffEntry) error {
+	nc := c.getClient(address)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute*5)
+	defer cancel()
+
+	stream, err := nc.Handoff(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		// the receiver gave up, its error comes with CloseAndRecv
+		if err := stream.Send(entry); err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+	}
+
+	_, err = stream.CloseAndRecv()
+	return err
+}
+
 func (c *Client) Predecessor(address string) (*grpc_api.PredecessorResponse, error) {
 	nc := c.getClient(address)
 
@@ -107,7 +205,7 @@ func (c *Client) Predecessor(address string) (*grpc_api.PredecessorResponse, err
 
 	retryable := func() error {
 		response, err = nc.Predecessor(ctx, &grpc_api.Empty{})
-		return err
+		return nonRetryable(err)
 	}
 
 	b := backoff.NewExponentialBackOff()
@@ -135,7 +233,7 @@ func (c *Client) Successor(address string) (*grpc_api.SuccessorResponse, error)
 
 	retryable := func() error {
 		response, err = nc.Successor(ctx, &grpc_api.Empty{})
-		return err
+		return nonRetryable(err)
 	}
 
 	b := backoff.NewExponentialBackOff()
@@ -150,6 +248,34 @@ func (c *Client) Successor(address string) (*grpc_api.SuccessorResponse, error)
 	return response, nil
 }
 
+func (c *Client) SuccessorList(address string) (*grpc_api.SuccessorListResponse, error) {
+	nc := c.getClient(address)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	var (
+		response *grpc_api.SuccessorListResponse
+		err      error
+	)
+
+	retryable := func() error {
+		response, err = nc.SuccessorList(ctx, &grpc_api.Empty{})
+		return err
+	}
+
+	b := backoff.NewExponentialBackOff()
+	b.MaxElapsedTime = time.Second * 10
+
+	backoff.Retry(retryable, b)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
 func (c *Client) Query(address string, key int64) *grpc_api.QueryResponse {
 	nc := c.getClient(address)
 
@@ -163,7 +289,41 @@ func (c *Client) Query(address string, key int64) *grpc_api.QueryResponse {
 
 	retryable := func() error {
 		response, err = nc.Query(ctx, &grpc_api.QueryRequest{Key: key})
-		return err
+		return nonRetryable(err)
+	}
+
+	b := backoff.NewExponentialBackOff()
+	b.MaxElapsedTime = time.Second * 10
+
+	backoff.Retry(retryable, b)
+
+	if err != nil {
+		return &grpc_api.QueryResponse{
+			Data:                    nil,
+			ResponsibleNodeId:       0,
+			ResponsibleNodeEndpoint: "",
+		}
+	}
+
+	return response
+}
+
+// ForwardQuery hands a recursive lookup over to the next hop. A hop that
+// could not be reached ends a traced path with its error.
+func (c *Client) ForwardQuery(address string, request *grpc_api.QueryRequest) (*grpc_api.QueryResponse, error) {
+	nc := c.getClient(address)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	var (
+		response *grpc_api.QueryResponse
+		err      error
+	)
+
+	retryable := func() error {
+		response, err = nc.Query(ctx, request)
+		return nonRetryable(err)
 	}
 
 	b := backoff.NewExponentialBackOff()
@@ -171,6 +331,22 @@ func (c *Client) Query(address string, key int64) *grpc_api.QueryResponse {
 
 	backoff.Retry(retryable, b)
 
+	if err != nil {
+		response = &grpc_api.QueryResponse{}
+		if request.Trace {
+			response.Path = []*grpc_api.Hop{{Endpoint: address, Error: err.Error()}}
+		}
+		return response, err
+	}
+
+	return response, nil
+}
+
+// QueryLocal reads key straight from the storage of the node at address,
+// without routing it to the key owner. A node failing to answer gives an
+// empty response.
+func (c *Client) QueryLocal(address string, key int64, name string) *grpc_api.QueryResponse {
+	response, err := c.ReadLocal(address, key, name)
 	if err != nil {
 		return &grpc_api.QueryResponse{
 			Data:                    nil,
@@ -182,6 +358,35 @@ func (c *Client) Query(address string, key int64) *grpc_api.QueryResponse {
 	return response
 }
 
+// ReadLocal is QueryLocal telling why the node at address did not answer.
+func (c *Client) ReadLocal(address string, key int64, name string) (*grpc_api.QueryResponse, error) {
+	nc := c.getClient(address)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	var (
+		response *grpc_api.QueryResponse
+		err      error
+	)
+
+	retryable := func() error {
+		response, err = nc.Query(ctx, &grpc_api.QueryRequest{Key: key, StrKey: name, Local: true})
+		return err
+	}
+
+	b := backoff.NewExponentialBackOff()
+	b.MaxElapsedTime = time.Second * 10
+
+	backoff.Retry(retryable, b)
+
+	if err != nil {
+		return &grpc_api.QueryResponse{}, err
+	}
+
+	return response, nil
+}
+
 func (c *Client) QueryWithStrKey(address string, key string) *grpc_api.QueryResponse {
 	nc := c.getClient(address)
 
@@ -262,7 +467,7 @@ func (c *Client) QueryAsync(address, key string, content chan []byte, errors cha
 
 	srv, err := nc.QueryStream(ctx, &grpc_api.QueryRequest{StrKey: key})
 	if err != nil {
-		log.Println("error when stablishing connection with stream server: %v", err.Error())
+		log.Printf("error when stablishing connection with stream server: %v", err.Error())
 		close(content)
 		errors <- err
 		close(errors)
@@ -300,7 +505,7 @@ func (c *Client) QueryAsync(address, key string, content chan []byte, errors cha
 	}
 }
 
-func (c *Client) RepSave(address string, key int64, value []byte) (*grpc_api.Empty, error) {
+func (c *Client) RepSave(address string, key int64, name string, value []byte, version int64) (*grpc_api.Empty, error) {
 	nc := c.getClient(address)
 
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
@@ -312,7 +517,7 @@ func (c *Client) RepSave(address string, key int64, value []byte) (*grpc_api.Emp
 	)
 
 	retryable := func() error {
-		response, err = nc.RepSave(ctx, &grpc_api.RepSaveRequest{Key: key, Value: value})
+		response, err = nc.RepSave(ctx, &grpc_api.RepSaveRequest{Key: key, Value: value, StrKey: name, Version: version})
 		return err
 	}
 
@@ -356,7 +561,7 @@ func (c *Client) SaveWithStrKey(address, key string, value []byte) (*grpc_api.Em
 	return response, nil
 }
 
-func (c *Client) Save(address string, key int64, value []byte) (*grpc_api.Empty, error) {
+func (c *Client) Save(address string, key int64, name string, value []byte, consistency string) (*grpc_api.Empty, error) {
 	nc := c.getClient(address)
 
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
@@ -368,8 +573,8 @@ func (c *Client) Save(address string, key int64, value []byte) (*grpc_api.Empty,
 	)
 
 	retryable := func() error {
-		response, err = nc.Save(ctx, &grpc_api.SaveRequest{Key: key, Data: value})
-		return err
+		response, err = nc.Save(ctx, &grpc_api.SaveRequest{Key: key, Data: value, StrKey: name, Consistency: consistency})
+		return nonRetryable(err)
 	}
 
 	b := backoff.NewExponentialBackOff()
@@ -384,7 +589,32 @@ func (c *Client) Save(address string, key int64, value []byte) (*grpc_api.Empty,
 	return response, nil
 }
 
-func (c *Client) Delete(address string, key int64) (*grpc_api.Empty, error) {
+// DirectSave saves value at address only if it owns key, a node that does not
+// turning the save down instead of forwarding it. It gives up sooner than
+// Save, the caller having the ring to fall back on, but outwaits the owner
+// waiting on its replicas.
+func (c *Client) DirectSave(address string, key int64, name string, value []byte, consistency string) error {
+	nc := c.getClient(address)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	var err error
+
+	retryable := func() error {
+		_, err = nc.Save(ctx, &grpc_api.SaveRequest{Key: key, Data: value, StrKey: name, Direct: true, Consistency: consistency})
+		return nonRetryable(err)
+	}
+
+	b := backoff.NewExponentialBackOff()
+	b.MaxElapsedTime = time.Second * 5
+
+	backoff.Retry(retryable, b)
+
+	return err
+}
+
+func (c *Client) Delete(address string, key int64, consistency string) (*grpc_api.Empty, error) {
 	nc := c.getClient(address)
 
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
@@ -396,8 +626,8 @@ func (c *Client) Delete(address string, key int64) (*grpc_api.Empty, error) {
 	)
 
 	retryable := func() error {
-		response, err = nc.Delete(ctx, &grpc_api.DeleteRequest{Key: key})
-		return err
+		response, err = nc.Delete(ctx, &grpc_api.DeleteRequest{Key: key, Consistency: consistency})
+		return nonRetryable(err)
 	}
 
 	b := backoff.NewExponentialBackOff()
@@ -412,6 +642,28 @@ func (c *Client) Delete(address string, key int64) (*grpc_api.Empty, error) {
 	return response, nil
 }
 
+// RepDelete deletes the copy of key the replica at address holds.
+func (c *Client) RepDelete(address string, key int64, version int64) error {
+	nc := c.getClient(address)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	var err error
+
+	retryable := func() error {
+		_, err = nc.Delete(ctx, &grpc_api.DeleteRequest{Key: key, Replica: true, Version: version})
+		return err
+	}
+
+	b := backoff.NewExponentialBackOff()
+	b.MaxElapsedTime = time.Second * 10
+
+	backoff.Retry(retryable, b)
+
+	return err
+}
+
 func (c *Client) DeleteWithStrKey(address string, key string) (*grpc_api.Empty, error) {
 	nc := c.getClient(address)
 
@@ -453,6 +705,143 @@ func (c *Client) Owner(address string, key int64) (*grpc_api.OwnerResponse, erro
 
 	retryable := func() error {
 		response, err = nc.Owner(ctx, &grpc_api.OwnerRequest{Key: key})
+		return nonRetryable(err)
+	}
+
+	b := backoff.NewExponentialBackOff()
+	b.MaxElapsedTime = time.Minute * 1
+
+	backoff.Retry(retryable, b)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+func (c *Client) ForwardOwner(address string, request *grpc_api.OwnerRequest) (*grpc_api.OwnerResponse, error) {
+	nc := c.getClient(address)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute*1)
+	defer cancel()
+
+	var (
+		response *grpc_api.OwnerResponse
+		err      error
+	)
+
+	retryable := func() error {
+		response, err = nc.Owner(ctx, request)
+		return nonRetryable(err)
+	}
+
+	b := backoff.NewExponentialBackOff()
+	b.MaxElapsedTime = time.Minute * 1
+
+	backoff.Retry(retryable, b)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// ClosestPreceding is a single hop of an iterative lookup, so it is not
+// retried: the caller moves on to another candidate once timeout expires.
+func (c *Client) ClosestPreceding(address string, key int64, count int, timeout time.Duration) (*grpc_api.ClosestPrecedingResponse, error) {
+	nc := c.getClient(address)
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	return nc.ClosestPreceding(ctx, &grpc_api.ClosestPrecedingRequest{Key: key, Count: int32(count)})
+}
+
+func (c *Client) FindNode(address string, key int64, count int, sender models.NodeRepresentation, timeout time.Duration) (*grpc_api.FindNodeResponse, error) {
+	nc := c.getClient(address)
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	return nc.FindNode(ctx, &grpc_api.FindNodeRequest{Key: key, Count: int32(count), Sender: &grpc_api.NodeInfo{Id: sender.Id, Endpoint: sender.Address}})
+}
+
+func (c *Client) Probe(address string, request *grpc_api.ProbeRequest, timeout time.Duration) (*grpc_api.ProbeResponse, error) {
+	nc := c.getClient(address)
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	return nc.Probe(ctx, request)
+}
+
+// SyncRepSave is RepSave waiting for the replica to store the value, for the
+// writes the owner has to count acknowledgements of. It gives up sooner, a
+// replica that is down failing the write rather than holding it.
+func (c *Client) SyncRepSave(address string, key int64, name string, value []byte, version int64) error {
+	nc := c.getClient(address)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*2)
+	defer cancel()
+
+	var err error
+
+	retryable := func() error {
+		_, err = nc.RepSave(ctx, &grpc_api.RepSaveRequest{Key: key, Value: value, StrKey: name, Version: version, Sync: true})
+		return err
+	}
+
+	b := backoff.NewExponentialBackOff()
+	b.MaxElapsedTime = time.Second * 2
+
+	backoff.Retry(retryable, b)
+
+	return err
+}
+
+func (c *Client) RepPut(address string, key int64, name string, value []byte, version int64) (*grpc_api.Empty, error) {
+	nc := c.getClient(address)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	var (
+		response *grpc_api.Empty
+		err      error
+	)
+
+	retryable := func() error {
+		response, err = nc.RepSave(ctx, &grpc_api.RepSaveRequest{Key: key, Value: value, StrKey: name, Version: version, Replace: true})
+		return err
+	}
+
+	b := backoff.NewExponentialBackOff()
+	b.MaxElapsedTime = time.Second * 10
+
+	backoff.Retry(retryable, b)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+func (c *Client) RangeQuery(address string, request *grpc_api.RangeQueryRequest) (*grpc_api.RangeQueryResponse, error) {
+	nc := c.getClient(address)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute*1)
+	defer cancel()
+
+	var (
+		response *grpc_api.RangeQueryResponse
+		err      error
+	)
+
+	retryable := func() error {
+		response, err = nc.RangeQuery(ctx, request)
 		return err
 	}
 
@@ -468,6 +857,161 @@ func (c *Client) Owner(address string, key int64) (*grpc_api.OwnerResponse, erro
 	return response, nil
 }
 
+func (c *Client) Load(address string) (*grpc_api.LoadResponse, error) {
+	nc := c.getClient(address)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	var (
+		response *grpc_api.LoadResponse
+		err      error
+	)
+
+	retryable := func() error {
+		response, err = nc.Load(ctx, &grpc_api.Empty{})
+		return err
+	}
+
+	b := backoff.NewExponentialBackOff()
+	b.MaxElapsedTime = time.Second * 10
+
+	backoff.Retry(retryable, b)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+func (c *Client) Fingers(address string) (*grpc_api.FingersResponse, error) {
+	nc := c.getClient(address)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	var (
+		response *grpc_api.FingersResponse
+		err      error
+	)
+
+	retryable := func() error {
+		response, err = nc.Fingers(ctx, &grpc_api.Empty{})
+		return err
+	}
+
+	b := backoff.NewExponentialBackOff()
+	b.MaxElapsedTime = time.Second * 10
+
+	backoff.Retry(retryable, b)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+func (c *Client) Keys(address string, start int64, end int64) (*grpc_api.KeysResponse, error) {
+	nc := c.getClient(address)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute*1)
+	defer cancel()
+
+	var (
+		response *grpc_api.KeysResponse
+		err      error
+	)
+
+	retryable := func() error {
+		response, err = nc.Keys(ctx, &grpc_api.KeysRequest{Start: start, End: end})
+		return err
+	}
+
+	b := backoff.NewExponentialBackOff()
+	b.MaxElapsedTime = time.Minute * 1
+
+	backoff.Retry(retryable, b)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+func (c *Client) Migrate(address string, request *grpc_api.MigrateRequest) (*grpc_api.Empty, error) {
+	nc := c.getClient(address)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	var (
+		response *grpc_api.Empty
+		err      error
+	)
+
+	retryable := func() error {
+		response, err = nc.Migrate(ctx, request)
+		return err
+	}
+
+	b := backoff.NewExponentialBackOff()
+	b.MaxElapsedTime = time.Second * 10
+
+	backoff.Retry(retryable, b)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+func (c *Client) MigrationStatus(address string, cluster bool) (*grpc_api.MigrationStatusResponse, error) {
+	nc := c.getClient(address)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute*1)
+	defer cancel()
+
+	var (
+		response *grpc_api.MigrationStatusResponse
+		err      error
+	)
+
+	retryable := func() error {
+		response, err = nc.MigrationStatus(ctx, &grpc_api.MigrationStatusRequest{Cluster: cluster})
+		return err
+	}
+
+	b := backoff.NewExponentialBackOff()
+	b.MaxElapsedTime = time.Minute * 1
+
+	backoff.Retry(retryable, b)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// nonRetryable stops retries on errors that are an answer rather than a
+// failure. Routing errors come from a broken ring and would only multiply at
+// every hop, and a node with no predecessor or successor will not get one
+// within a retry. Neither will a node of another cluster take the call.
+func nonRetryable(err error) error {
+	if models.IsConsistencyError(err) || models.IsRoutingError(err) {
+		return backoff.Permanent(err)
+	}
+	switch status.Code(err) {
+	case codes.NotFound, codes.FailedPrecondition, codes.PermissionDenied, codes.InvalidArgument:
+		return backoff.Permanent(err)
+	}
+	return err
+}
+
 func (c *Client) getClient(address string) grpc_api.DHTNodeClient {
 	var (
 		conn *grpc.ClientConn
@@ -475,9 +1019,17 @@ func (c *Client) getClient(address string) grpc_api.DHTNodeClient {
 		ok   bool
 	)
 
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	conn, ok = c.connections[address]
 	if !ok {
-		conn, err = grpc.Dial(address, grpc.WithInsecure(), grpc.WithBlock())
+		token := models.VirtualToken(address)
+		// not blocking on dial so calls to a dead node fail with their own
+		// timeout instead of hanging here
+		conn, err = grpc.Dial(models.PhysicalAddress(address), grpc.WithInsecure(),
+			grpc.WithUnaryInterceptor(c.unaryInterceptor(token)),
+			grpc.WithStreamInterceptor(c.streamInterceptor(token)))
 		if err != nil {
 			log.Println(err.Error())
 			panic(err.Error())
@@ -487,3 +1039,25 @@ func (c *Client) getClient(address string) grpc_api.DHTNodeClient {
 
 	return grpc_api.NewDHTNodeClient(conn)
 }
+
+// outgoing adds the virtual node token of the callee and the cluster
+// identity of the caller to the metadata.
+func (c *Client) outgoing(ctx context.Context, token string) context.Context {
+	ctx = metadata.AppendToOutgoingContext(ctx, models.VirtualNodeMetadataKey, token)
+	if c.identity != nil {
+		ctx = metadata.AppendToOutgoingContext(ctx, c.identity().Metadata()...)
+	}
+	return ctx
+}
+
+func (c *Client) unaryInterceptor(token string) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return invoker(c.outgoing(ctx, token), method, req, reply, cc, opts...)
+	}
+}
+
+func (c *Client) streamInterceptor(token string) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return streamer(c.outgoing(ctx, token), desc, cc, method, opts...)
+	}
+}
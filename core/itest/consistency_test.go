@@ -0,0 +1,66 @@
+package itest
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/raonismaneoto/CustomDHT/core/node"
+	"github.com/raonismaneoto/CustomDHT/core/router"
+)
+
+// TestDeleteWithReplicaDown deletes a key while one of its replicas is
+// down, for good: the delete is neither retried nor hinted by the time it
+// is back. A QUORUM read meeting that replica and the owner must find the
+// key deleted, not the value the replica still holds.
+func TestDeleteWithReplicaDown(t *testing.T) {
+	t.Setenv("REPLICATION_FACTOR", "3")
+	t.Setenv("HINT_WINDOW", "1")
+	nodes, servers := startRing(t, 3, router.ChordOverlay, node.RecursiveLookup)
+	waitForRing(t, nodes)
+
+	const key = 1234
+	if err := nodes[0].Save(key, "key", []byte("value"), node.ConsistencyAll); err != nil {
+		t.Fatalf("unable to save at ALL: %v", err)
+	}
+	response, err := nodes[0].Owner(key)
+	if err != nil {
+		t.Fatalf("unable to find the owner: %v", err)
+	}
+	var owner *node.Node
+	var replicas []int
+	for i, n := range nodes {
+		if n.Address() == response.OwnerNodeEndpoint {
+			owner = n
+		} else {
+			replicas = append(replicas, i)
+		}
+	}
+	stale, other := replicas[0], replicas[1]
+
+	servers[stale].Stop()
+	if err := owner.Delete(key, node.ConsistencyQuorum); err != nil {
+		t.Fatalf("unable to delete at QUORUM: %v", err)
+	}
+	// past the retries of the delete and the window of its hint
+	time.Sleep(12 * time.Second)
+	servers[stale] = serve(t, nodes[stale], listen(t, nodes[stale].Address()))
+	waitForRing(t, nodes)
+
+	servers[other].Stop()
+	read, err := owner.RoutedQuery(key, node.Route{Consistency: node.ConsistencyQuorum})
+	if err != nil {
+		t.Fatalf("unable to read at QUORUM: %v", err)
+	}
+	if len(read.Data) > 0 {
+		t.Fatalf("read %q back at QUORUM after deleting it", read.Data)
+	}
+}
+
+func listen(t *testing.T, address string) net.Listener {
+	lis, err := net.Listen("tcp", address)
+	if err != nil {
+		t.Fatalf("unable to listen on %v: %v", address, err)
+	}
+	return lis
+}
@@ -0,0 +1,249 @@
+// Package itest checks the protocols end to end: real nodes talking gRPC
+// over loopback all join through the same node at once, and the ring they
+// stabilize into must have every successor and predecessor right and
+// answer every lookup with the right owner, and every read what was last
+// written or deleted.
+package itest
+
+import (
+	"flag"
+	"io/ioutil"
+	"log"
+	"math/rand"
+	"net"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/raonismaneoto/CustomDHT/commons/grpc_api"
+	"github.com/raonismaneoto/CustomDHT/commons/helpers"
+	"github.com/raonismaneoto/CustomDHT/core/models"
+	"github.com/raonismaneoto/CustomDHT/core/node"
+	"github.com/raonismaneoto/CustomDHT/core/ring"
+	"github.com/raonismaneoto/CustomDHT/core/router"
+	Server "github.com/raonismaneoto/CustomDHT/core/server"
+	"github.com/raonismaneoto/CustomDHT/core/storage"
+	"google.golang.org/grpc"
+)
+
+var (
+	nodeCount = flag.Int("nodes", 30, "number of nodes joining at once")
+	lookups   = flag.Int("lookups", 500, "number of random lookups checked")
+	nodeLogs  = flag.Bool("logs", false, "keep the node logs on stderr")
+)
+
+const (
+	m = 16
+	// time the ring has to stabilize
+	stabilizeTimeout = 2 * time.Minute
+)
+
+func TestChordRing(t *testing.T) {
+	nodes, _ := startRing(t, *nodeCount, router.ChordOverlay, node.RecursiveLookup)
+	waitForRing(t, nodes)
+	checkLookups(t, nodes, successorOf)
+	checkRingReport(t, nodes[0])
+}
+
+func TestOneHopRing(t *testing.T) {
+	nodes, _ := startRing(t, *nodeCount, router.ChordOverlay, node.OneHopLookup)
+	waitForRing(t, nodes)
+	checkLookups(t, nodes, successorOf)
+	checkRingReport(t, nodes[0])
+}
+
+// TestKademliaOverlay has no ring to wait for, the owners being the nodes
+// closest to the keys by xor.
+func TestKademliaOverlay(t *testing.T) {
+	nodes, _ := startRing(t, *nodeCount, router.KademliaOverlay, node.RecursiveLookup)
+	checkLookups(t, nodes, xorClosest)
+}
+
+// startRing starts count nodes of overlay on loopback ports, all but the
+// first joining through it at once, and returns them with their servers.
+// Each node keeps its files in a temporary directory of its own, gone with
+// the test.
+func startRing(t *testing.T, count int, overlay string, lookupMode string) ([]*node.Node, []*grpc.Server) {
+	if testing.Short() {
+		t.Skip("starts a ring of real nodes")
+	}
+	if !*nodeLogs {
+		log.SetOutput(ioutil.Discard)
+		t.Cleanup(func() { log.SetOutput(os.Stderr) })
+	}
+
+	t.Setenv("OVERLAY", overlay)
+	t.Setenv("LOOKUP_MODE", lookupMode)
+	setDefaultEnv(t, "STABILIZE_INTERVAL", "1")
+	setDefaultEnv(t, "FIX_FINGERS_INTERVAL", "1")
+	setDefaultEnv(t, "CHECK_SUCC_INTERVAL", "2")
+
+	// the legacy hash collides too often on loopback addresses, and ids
+	// colliding while joining at the same time are not what is checked here
+	keySpace := models.KeySpace{M: m, Hash: helpers.Sha1Hash}
+	var nodes []*node.Node
+	var servers []*grpc.Server
+	taken := make(map[int64]bool)
+	for len(nodes) < count {
+		lis, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatalf("unable to listen on loopback: %v", err)
+		}
+		// a port whose id is taken is held until the end, so it is not
+		// handed out again
+		t.Cleanup(func() { lis.Close() })
+		address := lis.Addr().String()
+		id := helpers.GetHashWith(address, m, keySpace.Hash)
+		if taken[id] {
+			continue
+		}
+		taken[id] = true
+		n := node.New(id, address, keySpace, storage.NewIn(models.Mem, t.TempDir()))
+		servers = append(servers, serve(t, n, lis))
+		nodes = append(nodes, n)
+	}
+
+	bootstrap := nodes[0]
+	bootstrap.Start(nil)
+
+	var wg sync.WaitGroup
+	for _, n := range nodes[1:] {
+		wg.Add(1)
+		go func(n *node.Node) {
+			defer wg.Done()
+			n.Start([]string{bootstrap.Address()})
+		}(n)
+	}
+	wg.Wait()
+	t.Logf("%d nodes joined", len(nodes))
+	return nodes, servers
+}
+
+func serve(t *testing.T, n *node.Node, lis net.Listener) *grpc.Server {
+	server := Server.New([]*node.Node{n})
+	s := grpc.NewServer(server.Interceptors()...)
+	grpc_api.RegisterDHTNodeServer(s, server)
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+	return s
+}
+
+// waitForRing waits for every successor and predecessor to be right, and
+// then for the fingers to be refreshed.
+func waitForRing(t *testing.T, nodes []*node.Node) {
+	started := time.Now()
+	deadline := started.Add(stabilizeTimeout)
+	problems := ringProblems(nodes)
+	for len(problems) > 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Second)
+		problems = ringProblems(nodes)
+	}
+	if len(problems) > 0 {
+		t.Fatalf("ring did not stabilize within %v:\n%s", stabilizeTimeout, strings.Join(problems, "\n"))
+	}
+	t.Logf("ring stabilized after %v", time.Since(started).Round(time.Second))
+
+	// fingers are refreshed one per tick, give them a full round
+	time.Sleep(time.Duration(m) * time.Second)
+}
+
+// ringProblems compares every node pointers with the ones the sorted ids
+// give.
+func ringProblems(nodes []*node.Node) []string {
+	sorted := append([]*node.Node{}, nodes...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Id() < sorted[j].Id() })
+
+	var problems []string
+	for i, n := range sorted {
+		expectedSucc := sorted[(i+1)%len(sorted)]
+		expectedPred := sorted[(i-1+len(sorted))%len(sorted)]
+
+		succ, err := n.Successor()
+		if err != nil || succ.Address != expectedSucc.Address() {
+			problems = append(problems, "successor of "+strconv.FormatInt(n.Id(), 10)+" is "+describe(succ, err)+", expected "+strconv.FormatInt(expectedSucc.Id(), 10))
+		}
+		pred, err := n.Predecessor()
+		if err != nil || pred.Address != expectedPred.Address() {
+			problems = append(problems, "predecessor of "+strconv.FormatInt(n.Id(), 10)+" is "+describe(&pred, err)+", expected "+strconv.FormatInt(expectedPred.Id(), 10))
+		}
+	}
+	return problems
+}
+
+func checkLookups(t *testing.T, nodes []*node.Node, expectedOwner func([]int64, int64) int64) {
+	ids := sortedIds(nodes)
+	r := rand.New(rand.NewSource(1))
+	failures := 0
+	for i := 0; i < *lookups; i++ {
+		from := nodes[r.Intn(len(nodes))]
+		key := r.Int63n(ring.Size(m))
+		expected := expectedOwner(ids, key)
+		owner, err := from.Owner(key)
+		if err != nil {
+			failures++
+			t.Errorf("lookup of %d from %d failed: %v", key, from.Id(), err)
+			continue
+		}
+		if owner.OwnerNodeId != expected {
+			failures++
+			t.Errorf("lookup of %d from %d reached %d, expected %d", key, from.Id(), owner.OwnerNodeId, expected)
+		}
+	}
+	if failures > 0 {
+		t.Fatalf("%d of %d lookups failed", failures, *lookups)
+	}
+}
+
+func checkRingReport(t *testing.T, n *node.Node) {
+	report := n.CheckRing()
+	if !report.Consistent {
+		t.Fatalf("ring checker found %d problems:\n%s", len(report.Problems), strings.Join(report.Problems, "\n"))
+	}
+	if len(report.Ring) != *nodeCount {
+		t.Fatalf("ring checker walked %d nodes, expected %d", len(report.Ring), *nodeCount)
+	}
+}
+
+func describe(n *models.NodeRepresentation, err error) string {
+	if err != nil || n == nil {
+		return "unset"
+	}
+	return strconv.FormatInt(n.Id, 10)
+}
+
+func sortedIds(nodes []*node.Node) []int64 {
+	ids := make([]int64, len(nodes))
+	for i, n := range nodes {
+		ids[i] = n.Id()
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+func successorOf(ids []int64, key int64) int64 {
+	i := sort.Search(len(ids), func(i int) bool { return ids[i] >= key })
+	if i == len(ids) {
+		return ids[0]
+	}
+	return ids[i]
+}
+
+func xorClosest(ids []int64, key int64) int64 {
+	closest := ids[0]
+	for _, id := range ids {
+		if id^key < closest^key {
+			closest = id
+		}
+	}
+	return closest
+}
+
+func setDefaultEnv(t *testing.T, key, value string) {
+	if os.Getenv(key) == "" {
+		t.Setenv(key, value)
+	}
+}
@@ -1,19 +1,32 @@
 package main
 
 import (
+	"bufio"
+	"flag"
 	"log"
 	"net"
 	"os"
 	"os/exec"
+	"os/signal"
 	"strconv"
+	"strings"
+	"syscall"
 
 	"github.com/raonismaneoto/CustomDHT/commons/grpc_api"
 	"github.com/raonismaneoto/CustomDHT/commons/helpers"
+	"github.com/raonismaneoto/CustomDHT/core/models"
+	"github.com/raonismaneoto/CustomDHT/core/node"
 	Server "github.com/raonismaneoto/CustomDHT/core/server"
+	"github.com/raonismaneoto/CustomDHT/core/storage"
 	"google.golang.org/grpc"
 )
 
 func main() {
+	bootstrap := flag.Bool("bootstrap", os.Getenv("BOOTSTRAP") == "true", "start a new ring instead of joining one")
+	seedList := flag.String("seeds", os.Getenv("SEEDS"), "comma separated addresses of nodes to join the ring through, tried in order")
+	seedsFile := flag.String("seeds-file", os.Getenv("SEEDS_FILE"), "file listing seed addresses, one per line")
+	flag.Parse()
+
 	port := os.Getenv("NODE_PORT")
 	address := helpers.GetOutboundIP() + ":" + port
 	m, err := strconv.Atoi(os.Getenv("M"))
@@ -22,19 +35,34 @@ func main() {
 		panic("m must be an integer")
 	}
 
-	partnerAddress := os.Getenv("PARTNER_FULL_ADDR")
-	partnerId, err := strconv.ParseInt(os.Getenv("PARTNER_ID"), 10, 64)
-
+	seeds, err := readSeeds(*seedList, *seedsFile)
 	if err != nil {
-		panic("partnerId must be an integer")
+		log.Fatalf("unable to read the seeds: %v", err)
+	}
+	if !*bootstrap && len(seeds) == 0 {
+		log.Fatal("no seeds to join the ring through, set SEEDS or SEEDS_FILE, or BOOTSTRAP=true to start a new ring")
+	}
+
+	hashAlgorithm := os.Getenv("HASH_ALGORITHM")
+	if hashAlgorithm == "" {
+		hashAlgorithm = helpers.LegacyHash
 	}
+	dataDir := helpers.DataDir()
+	keySpace := node.LoadKeySpace(dataDir, models.KeySpace{M: m, Hash: hashAlgorithm})
 
-	nodeId := helpers.GetHash(address, m)
-	// create or update and check ids file in the nfs
-	startingNode := os.Getenv("NETWORK_STARTING_NODE")
-	if startingNode == "true" {
-		partnerAddress = address
+	store := storage.NewIn(models.GetMemTypeFromString(os.Getenv("STORAGE_TYPE")), dataDir)
+	nodes := make([]*node.Node, node.VirtualNodeCount())
+	for token := range nodes {
+		vAddress := models.VirtualAddress(address, token)
+		vId := helpers.GetHashWith(vAddress, keySpace.M, keySpace.Hash)
+		if persistedId, err := helpers.ReadIdFile(node.IdFilePath(dataDir, vAddress)); err == nil {
+			log.Println("using persisted node id for " + vAddress)
+			vId = persistedId
+		}
+		nodes[token] = node.New(vId, vAddress, keySpace, store)
 	}
+	node.LinkSiblings(nodes)
+	nodeId := nodes[0].Id()
 
 	helpers.SetupLogging(nodeId)
 	helpers.PeriodicInvocation(func() {
@@ -51,16 +79,70 @@ func main() {
 		log.Fatalf("Error %v", err)
 	}
 
-	s := grpc.NewServer()
-	nodeNodeServer := Server.New(nodeId, address, m)
+	nodeNodeServer := Server.New(nodes)
+	s := grpc.NewServer(nodeNodeServer.Interceptors()...)
 	grpc_api.RegisterDHTNodeServer(s, nodeNodeServer)
 
-	log.Println("NodeServer listening at %v", lis.Addr())
+	log.Printf("NodeServer listening at %v", lis.Addr())
 
-	go nodeNodeServer.Node.Start(partnerId, partnerAddress)
+	go func() {
+		// virtual nodes join one at a time so they never race each other
+		// for the same spot in the ring
+		for token, n := range nodeNodeServer.Nodes {
+			switch {
+			case !*bootstrap:
+				n.Start(seeds)
+			case token == 0:
+				log.Println("bootstrapping a new ring")
+				n.Start(nil)
+			default:
+				n.Start([]string{address})
+			}
+		}
+	}()
+
+	go func() {
+		sigs := make(chan os.Signal, 1)
+		signal.Notify(sigs, syscall.SIGTERM, os.Interrupt)
+		select {
+		case sig := <-sigs:
+			log.Println("received " + sig.String() + ", leaving the ring")
+			nodeNodeServer.LeaveAll()
+		case <-nodeNodeServer.Left():
+		}
+		s.GracefulStop()
+	}()
 
 	log.Println("going to start grpc NodeServer listener")
 	if err := s.Serve(lis); err != nil {
 		log.Fatalf("failed to serve: %v", err)
 	}
 }
+
+// readSeeds takes the seeds listed in seeds followed by the ones in file.
+func readSeeds(seeds, file string) ([]string, error) {
+	var list []string
+	for _, seed := range strings.Split(seeds, ",") {
+		if seed = strings.TrimSpace(seed); seed != "" {
+			list = append(list, seed)
+		}
+	}
+	if file == "" {
+		return list, nil
+	}
+
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		list = append(list, line)
+	}
+	return list, scanner.Err()
+}
@@ -0,0 +1,414 @@
+package membership
+
+import (
+	"log"
+	"math"
+	"math/rand"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/raonismaneoto/CustomDHT/commons/grpc_api"
+	"github.com/raonismaneoto/CustomDHT/commons/helpers"
+	"github.com/raonismaneoto/CustomDHT/core/metrics"
+	"github.com/raonismaneoto/CustomDHT/core/models"
+)
+
+const (
+	Alive int32 = iota
+	Suspect
+	Dead
+)
+
+var stateNames = map[int32]string{Alive: "alive", Suspect: "suspect", Dead: "dead"}
+
+func StateName(state int32) string {
+	return stateNames[state]
+}
+
+type Member struct {
+	Id          int64
+	Address     string
+	State       int32
+	Incarnation uint64
+	changed     time.Time
+}
+
+func (m Member) update() *grpc_api.MemberUpdate {
+	return &grpc_api.MemberUpdate{Id: m.Id, Endpoint: m.Address, State: m.State, Incarnation: m.Incarnation}
+}
+
+type Transport interface {
+	Probe(address string, request *grpc_api.ProbeRequest, timeout time.Duration) (*grpc_api.ProbeResponse, error)
+}
+
+type Config struct {
+	ProbeInterval    time.Duration
+	ProbeTimeout     time.Duration
+	IndirectProbes   int
+	SuspicionTimeout time.Duration
+	// DeadRetention is how long a dead member is kept, so the ones still
+	// mentioning it do not bring it back
+	DeadRetention time.Duration
+	GossipSize    int
+}
+
+// ConfigFromEnv reads the SWIM_* variables, all durations in milliseconds.
+func ConfigFromEnv() Config {
+	return Config{
+		ProbeInterval:    envMillis("SWIM_PROBE_INTERVAL", 1000),
+		ProbeTimeout:     envMillis("SWIM_PROBE_TIMEOUT", 500),
+		IndirectProbes:   helpers.EnvInt("SWIM_INDIRECT_PROBES", 1, 3),
+		SuspicionTimeout: envMillis("SWIM_SUSPICION_TIMEOUT", 5000),
+		DeadRetention:    envMillis("SWIM_DEAD_RETENTION", 10*60*1000),
+		GossipSize:       helpers.EnvInt("SWIM_GOSSIP_SIZE", 1, 8),
+	}
+}
+
+func envMillis(name string, fallback int) time.Duration {
+	return time.Duration(helpers.EnvInt(name, 1, fallback)) * time.Millisecond
+}
+
+type broadcast struct {
+	update    *grpc_api.MemberUpdate
+	transmits int
+}
+
+// List is the SWIM membership view of one node: members are probed one at
+// a time, the ones not answering even through others become suspects and
+// then dead, and every change travels piggybacked on the probes.
+type List struct {
+	mu         sync.Mutex
+	self       Member
+	members    map[string]*Member
+	order      []string
+	next       int
+	broadcasts map[string]*broadcast
+	transport  Transport
+	config     Config
+	onChange   func(Member)
+	stop       chan struct{}
+}
+
+func New(self models.NodeRepresentation, transport Transport, config Config, onChange func(Member)) *List {
+	return &List{
+		self:       Member{Id: self.Id, Address: self.Address, State: Alive},
+		members:    make(map[string]*Member),
+		broadcasts: make(map[string]*broadcast),
+		transport:  transport,
+		config:     config,
+		onChange:   onChange,
+		stop:       make(chan struct{}),
+	}
+}
+
+func (l *List) Start() {
+	go func() {
+		ticker := time.NewTicker(l.config.ProbeInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-l.stop:
+				return
+			case <-ticker.C:
+				l.probeNext()
+				l.expire()
+			}
+		}
+	}()
+}
+
+func (l *List) Stop() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	select {
+	case <-l.stop:
+	default:
+		close(l.stop)
+	}
+}
+
+// Add starts tracking a node learned from the ring. Members already known,
+// dead ones included, are left as they are.
+func (l *List) Add(node models.NodeRepresentation) {
+	if node.Address == "" || node.Address == l.self.Address {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, ok := l.members[node.Address]; ok {
+		return
+	}
+	l.members[node.Address] = &Member{Id: node.Id, Address: node.Address, State: Alive, changed: time.Now()}
+	l.order = append(l.order, node.Address)
+}
+
+// State tells whether address is a known member and in which state.
+func (l *List) State(address string) (int32, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	member, ok := l.members[address]
+	if !ok {
+		return Alive, false
+	}
+	return member.State, true
+}
+
+func (l *List) Members() []Member {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	members := make([]Member, 0, len(l.members))
+	for _, member := range l.members {
+		members = append(members, *member)
+	}
+	sort.Slice(members, func(i, j int) bool { return members[i].Id < members[j].Id })
+	return members
+}
+
+// Handle answers a probe. A probe with a target asks this node to probe it
+// on behalf of the sender.
+func (l *List) Handle(request *grpc_api.ProbeRequest) *grpc_api.ProbeResponse {
+	if request.GetSender() != nil {
+		l.merge(request.Sender)
+	}
+	for _, update := range request.Updates {
+		l.merge(update)
+	}
+
+	ack := true
+	if request.Target != "" {
+		metrics.Inc(metrics.SwimIndirectProbes)
+		ack = l.probe(request.Target, "")
+	}
+	return &grpc_api.ProbeResponse{Ack: ack, Updates: l.gossip()}
+}
+
+func (l *List) probeNext() {
+	target, ok := l.nextTarget()
+	if !ok {
+		return
+	}
+	metrics.Inc(metrics.SwimProbes)
+	if l.probe(target.Address, "") {
+		return
+	}
+
+	relays := l.helpers(target.Address)
+	acks := make(chan bool, len(relays))
+	for _, relay := range relays {
+		go func(relay string) {
+			acks <- l.probe(relay, target.Address)
+		}(relay)
+	}
+	for range relays {
+		if <-acks {
+			return
+		}
+	}
+
+	log.Println("member " + target.Address + " did not answer any probe, suspecting it")
+	l.merge(&grpc_api.MemberUpdate{Id: target.Id, Endpoint: target.Address, State: Suspect, Incarnation: target.Incarnation})
+}
+
+// probe sends a probe to address, through it when target is set, and takes
+// in the gossip of the answer.
+func (l *List) probe(address, target string) bool {
+	timeout := l.config.ProbeTimeout
+	if target != "" {
+		// the helper needs the time of its own probe on top
+		timeout *= 2
+	}
+	l.mu.Lock()
+	sender := l.self.update()
+	l.mu.Unlock()
+
+	response, err := l.transport.Probe(address, &grpc_api.ProbeRequest{Sender: sender, Target: target, Updates: l.gossip()}, timeout)
+	if err != nil {
+		return false
+	}
+	for _, update := range response.Updates {
+		l.merge(update)
+	}
+	return response.Ack
+}
+
+// nextTarget walks the members round robin, shuffling them at each round.
+func (l *List) nextTarget() (Member, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for tries := 0; tries <= len(l.order); tries++ {
+		if l.next >= len(l.order) {
+			l.next = 0
+			rand.Shuffle(len(l.order), func(i, j int) { l.order[i], l.order[j] = l.order[j], l.order[i] })
+		}
+		if len(l.order) == 0 {
+			return Member{}, false
+		}
+		member := l.members[l.order[l.next]]
+		l.next++
+		if member.State != Dead {
+			return *member, true
+		}
+	}
+	return Member{}, false
+}
+
+func (l *List) helpers(exclude string) []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	var candidates []string
+	for address, member := range l.members {
+		if address != exclude && member.State == Alive {
+			candidates = append(candidates, address)
+		}
+	}
+	rand.Shuffle(len(candidates), func(i, j int) { candidates[i], candidates[j] = candidates[j], candidates[i] })
+	if len(candidates) > l.config.IndirectProbes {
+		candidates = candidates[:l.config.IndirectProbes]
+	}
+	return candidates
+}
+
+// expire declares dead the suspects nobody cleared in time and forgets the
+// dead once kept for the retention, long enough for the ring to have
+// dropped them.
+func (l *List) expire() {
+	l.mu.Lock()
+	var expired []*grpc_api.MemberUpdate
+	for address, member := range l.members {
+		age := time.Since(member.changed)
+		if member.State == Suspect && age > l.config.SuspicionTimeout {
+			expired = append(expired, &grpc_api.MemberUpdate{Id: member.Id, Endpoint: member.Address, State: Dead, Incarnation: member.Incarnation})
+		}
+		if member.State == Dead && age > l.config.DeadRetention {
+			delete(l.members, address)
+			l.order = remove(l.order, address)
+		}
+	}
+	l.mu.Unlock()
+
+	for _, update := range expired {
+		log.Println("suspect " + update.Endpoint + " was not cleared in time, declaring it dead")
+		l.merge(update)
+	}
+}
+
+// merge applies an update following the SWIM precedence: a higher
+// incarnation wins, at the same incarnation suspect beats alive, and dead
+// beats everything but the member alive at a later incarnation.
+func (l *List) merge(update *grpc_api.MemberUpdate) {
+	l.mu.Lock()
+	if update.Endpoint == l.self.Address {
+		if update.State != Alive && update.Incarnation >= l.self.Incarnation {
+			// refute by outliving the rumour
+			l.self.Incarnation = update.Incarnation + 1
+			l.queue(l.self.update())
+			metrics.Inc(metrics.SwimRefutes)
+		}
+		l.mu.Unlock()
+		return
+	}
+
+	member, known := l.members[update.Endpoint]
+	if !known {
+		if update.State == Dead {
+			l.mu.Unlock()
+			return
+		}
+		member = &Member{Id: update.Id, Address: update.Endpoint, State: Alive}
+		l.members[update.Endpoint] = member
+		l.order = append(l.order, update.Endpoint)
+	} else if !supersedes(update, member) {
+		if member.State == Dead && update.State != Dead {
+			// spread the death again, for the member itself to hear of it
+			// and refute it if it is back
+			l.queue(member.update())
+		}
+		l.mu.Unlock()
+		return
+	}
+
+	changed := !known || member.State != update.State
+	member.Id = update.Id
+	member.State = update.State
+	member.Incarnation = update.Incarnation
+	member.changed = time.Now()
+	l.queue(member.update())
+	snapshot := *member
+	l.mu.Unlock()
+
+	if !changed {
+		return
+	}
+	switch snapshot.State {
+	case Suspect:
+		metrics.Inc(metrics.SwimSuspects)
+	case Dead:
+		metrics.Inc(metrics.SwimDeaths)
+	}
+	log.Println("member " + snapshot.Address + " is " + StateName(snapshot.State) + " at incarnation " + strconv.FormatUint(snapshot.Incarnation, 10))
+	if l.onChange != nil {
+		// callbacks may call other nodes, and the probe being answered
+		// must not wait for them
+		go l.onChange(snapshot)
+	}
+}
+
+func supersedes(update *grpc_api.MemberUpdate, member *Member) bool {
+	switch update.State {
+	case Alive:
+		return update.Incarnation > member.Incarnation
+	case Suspect:
+		switch member.State {
+		case Alive:
+			return update.Incarnation >= member.Incarnation
+		case Suspect:
+			return update.Incarnation > member.Incarnation
+		}
+		// only the member itself, alive again, takes back its death
+		return false
+	default:
+		return member.State != Dead || update.Incarnation > member.Incarnation
+	}
+}
+
+// queue keeps the latest update of each member, to be piggybacked a number
+// of times growing with the log of the membership size.
+func (l *List) queue(update *grpc_api.MemberUpdate) {
+	l.broadcasts[update.Endpoint] = &broadcast{update: update}
+}
+
+func (l *List) gossip() []*grpc_api.MemberUpdate {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	pending := make([]*broadcast, 0, len(l.broadcasts))
+	for _, b := range l.broadcasts {
+		pending = append(pending, b)
+	}
+	sort.Slice(pending, func(i, j int) bool { return pending[i].transmits < pending[j].transmits })
+	if len(pending) > l.config.GossipSize {
+		pending = pending[:l.config.GossipSize]
+	}
+
+	limit := 3 * int(math.Ceil(math.Log2(float64(len(l.members)+2))))
+	updates := make([]*grpc_api.MemberUpdate, 0, len(pending))
+	for _, b := range pending {
+		updates = append(updates, b.update)
+		b.transmits++
+		if b.transmits >= limit {
+			delete(l.broadcasts, b.update.Endpoint)
+		}
+	}
+	return updates
+}
+
+func remove(addresses []string, address string) []string {
+	for i, candidate := range addresses {
+		if candidate == address {
+			return append(addresses[:i], addresses[i+1:]...)
+		}
+	}
+	return addresses
+}
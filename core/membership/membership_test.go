@@ -0,0 +1,128 @@
+package membership
+
+import (
+	"testing"
+	"time"
+
+	"github.com/raonismaneoto/CustomDHT/commons/grpc_api"
+	"github.com/raonismaneoto/CustomDHT/core/models"
+)
+
+const other = "node-2"
+
+func newList(config Config) *List {
+	return New(models.NodeRepresentation{Id: 1, Address: "node-1"}, nil, config, nil)
+}
+
+func update(state int32, incarnation uint64) *grpc_api.MemberUpdate {
+	return &grpc_api.MemberUpdate{Id: 2, Endpoint: other, State: state, Incarnation: incarnation}
+}
+
+func TestMergePrecedence(t *testing.T) {
+	cases := []struct {
+		state       int32
+		incarnation uint64
+		update      *grpc_api.MemberUpdate
+		expected    int32
+		expectedInc uint64
+	}{
+		// a higher incarnation wins
+		{Alive, 1, update(Alive, 2), Alive, 2},
+		{Suspect, 1, update(Alive, 2), Alive, 2},
+		{Alive, 2, update(Alive, 1), Alive, 2},
+		{Suspect, 1, update(Alive, 1), Suspect, 1},
+		// at the same incarnation suspect beats alive
+		{Alive, 1, update(Suspect, 1), Suspect, 1},
+		{Alive, 2, update(Suspect, 1), Alive, 2},
+		{Suspect, 1, update(Suspect, 2), Suspect, 2},
+		// dead beats everything but a later life
+		{Alive, 3, update(Dead, 1), Dead, 1},
+		{Suspect, 1, update(Dead, 1), Dead, 1},
+		{Dead, 1, update(Alive, 1), Dead, 1},
+		{Dead, 1, update(Suspect, 5), Dead, 1},
+		{Dead, 1, update(Alive, 2), Alive, 2},
+	}
+	for _, c := range cases {
+		l := newList(Config{})
+		l.members[other] = &Member{Id: 2, Address: other, State: c.state, Incarnation: c.incarnation}
+		l.merge(c.update)
+		member := l.members[other]
+		if member.State != c.expected || member.Incarnation != c.expectedInc {
+			t.Errorf("%v at %v merging %v at %v is %v at %v, expected %v at %v",
+				StateName(c.state), c.incarnation, StateName(c.update.State), c.update.Incarnation,
+				StateName(member.State), member.Incarnation, StateName(c.expected), c.expectedInc)
+		}
+	}
+}
+
+func TestMergeUnknown(t *testing.T) {
+	l := newList(Config{})
+	l.merge(update(Dead, 1))
+	if _, known := l.State(other); known {
+		t.Errorf("a member first heard of dead was taken in")
+	}
+	l.merge(update(Suspect, 1))
+	if state, _ := l.State(other); state != Suspect {
+		t.Errorf("a member first heard of as suspect is %v", StateName(state))
+	}
+}
+
+func TestRefute(t *testing.T) {
+	l := newList(Config{})
+	l.merge(&grpc_api.MemberUpdate{Id: 1, Endpoint: "node-1", State: Suspect, Incarnation: 3})
+	if l.self.Incarnation != 4 || l.self.State != Alive {
+		t.Errorf("suspected at 3, self is %v at %v, expected alive at 4", StateName(l.self.State), l.self.Incarnation)
+	}
+	l.merge(&grpc_api.MemberUpdate{Id: 1, Endpoint: "node-1", State: Dead, Incarnation: 2})
+	if l.self.Incarnation != 4 {
+		t.Errorf("an older rumour moved self to incarnation %v", l.self.Incarnation)
+	}
+}
+
+func TestExpire(t *testing.T) {
+	config := Config{SuspicionTimeout: time.Second, DeadRetention: time.Minute}
+	cases := []struct {
+		state    int32
+		age      time.Duration
+		expected int32
+		known    bool
+	}{
+		{Suspect, 500 * time.Millisecond, Suspect, true},
+		{Suspect, 2 * time.Second, Dead, true},
+		{Alive, time.Hour, Alive, true},
+		// the dead are kept through the retention, not the suspicion timeout
+		{Dead, 2 * time.Second, Dead, true},
+		{Dead, 2 * time.Minute, Dead, false},
+	}
+	for _, c := range cases {
+		l := newList(config)
+		l.members[other] = &Member{Id: 2, Address: other, State: c.state, changed: time.Now().Add(-c.age)}
+		l.order = []string{other}
+		l.expire()
+		state, known := l.State(other)
+		if known != c.known || (known && state != c.expected) {
+			t.Errorf("%v for %v is %v, known %v, expected %v, known %v", StateName(c.state), c.age, StateName(state), known, StateName(c.expected), c.known)
+		}
+	}
+}
+
+// TestDeadStaysDead checks a dead member is not brought back by the ring
+// mentioning it or by older rumours, the death being spread again for the
+// member to refute it.
+func TestDeadStaysDead(t *testing.T) {
+	l := newList(Config{DeadRetention: time.Minute})
+	l.members[other] = &Member{Id: 2, Address: other, State: Dead, Incarnation: 1, changed: time.Now()}
+	l.order = []string{other}
+
+	l.Add(models.NodeRepresentation{Id: 2, Address: other})
+	l.merge(update(Alive, 1))
+	if state, _ := l.State(other); state != Dead {
+		t.Fatalf("a dead member was brought back as %v", StateName(state))
+	}
+	if b, ok := l.broadcasts[other]; !ok || b.update.State != Dead {
+		t.Errorf("the death was not spread again")
+	}
+	if _, ok := l.nextTarget(); ok {
+		t.Errorf("a dead member was probed")
+	}
+}
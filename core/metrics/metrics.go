@@ -0,0 +1,68 @@
+package metrics
+
+import "sync"
+
+const (
+	RoutingLoops = "routing_loops"
+	TooManyHops  = "too_many_hops"
+
+	SwimProbes         = "swim_probes"
+	SwimIndirectProbes = "swim_indirect_probes"
+	SwimSuspects       = "swim_suspects"
+	SwimDeaths         = "swim_deaths"
+	SwimRefutes        = "swim_refutes"
+
+	PartitionsDetected = "partitions_detected"
+	RingMerges         = "ring_merges"
+	KeysRehomed        = "keys_rehomed"
+
+	ReplicationFailures = "replication_failures"
+	ReplicaReads        = "replica_reads"
+
+	HintsStored   = "hints_stored"
+	HintsReplayed = "hints_replayed"
+	HintsExpired  = "hints_expired"
+	HintsPending  = "hints_pending"
+
+	OwnerCacheHits          = "owner_cache_hits"
+	OwnerCacheMisses        = "owner_cache_misses"
+	OwnerCacheInvalidations = "owner_cache_invalidations"
+	OwnerCacheHitRatePct    = "owner_cache_hit_rate_pct"
+
+	LookupMicros        = "lookup_micros"
+	LookupHops          = "lookup_hops"
+	HopLatencyAvgMicros = "hop_latency_avg_micros"
+)
+
+var (
+	mu       sync.Mutex
+	counters = make(map[string]int64)
+)
+
+func Inc(name string) {
+	Add(name, 1)
+}
+
+func Add(name string, delta int64) {
+	mu.Lock()
+	defer mu.Unlock()
+	counters[name] += delta
+}
+
+// Snapshot copies the counters of the process, so shared by all of its
+// virtual nodes, along with the averages derived from them.
+func Snapshot() map[string]int64 {
+	mu.Lock()
+	defer mu.Unlock()
+	snapshot := make(map[string]int64, len(counters)+2)
+	for name, value := range counters {
+		snapshot[name] = value
+	}
+	if hops := counters[LookupHops]; hops > 0 {
+		snapshot[HopLatencyAvgMicros] = counters[LookupMicros] / hops
+	}
+	if lookups := counters[OwnerCacheHits] + counters[OwnerCacheMisses]; lookups > 0 {
+		snapshot[OwnerCacheHitRatePct] = counters[OwnerCacheHits] * 100 / lookups
+	}
+	return snapshot
+}
@@ -0,0 +1,93 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	ClusterMetadataKey  = "dht-cluster"
+	KeySpaceMetadataKey = "dht-key-space"
+)
+
+// ClusterIdentity is what the nodes of a cluster have in common, its name and
+// key space. A node in the middle of a key space migration is in both the
+// previous and the current one.
+type ClusterIdentity struct {
+	Name      string
+	KeySpaces []KeySpace
+}
+
+func (k KeySpace) String() string {
+	return strconv.Itoa(k.M) + "/" + k.Hash
+}
+
+// ParseKeySpace reads a key space written as M/hash.
+func ParseKeySpace(s string) (KeySpace, error) {
+	parts := strings.SplitN(s, "/", 2)
+	if len(parts) < 2 {
+		return KeySpace{}, errors.New("invalid key space " + s + ", expected M/hash")
+	}
+	m, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return KeySpace{}, errors.New("invalid M in key space " + s)
+	}
+	return KeySpace{M: m, Hash: parts[1]}, nil
+}
+
+// ParseClusterIdentity reads an identity as sent in the request metadata or
+// the handshake.
+func ParseClusterIdentity(name string, keySpaces []string) (ClusterIdentity, error) {
+	if name == "" {
+		return ClusterIdentity{}, errors.New("no cluster identity was sent, the caller may run an older version")
+	}
+	identity := ClusterIdentity{Name: name}
+	for _, s := range keySpaces {
+		keySpace, err := ParseKeySpace(s)
+		if err != nil {
+			return ClusterIdentity{}, err
+		}
+		identity.KeySpaces = append(identity.KeySpaces, keySpace)
+	}
+	return identity, nil
+}
+
+func (c ClusterIdentity) KeySpaceStrings() []string {
+	var keySpaces []string
+	for _, keySpace := range c.KeySpaces {
+		keySpaces = append(keySpaces, keySpace.String())
+	}
+	return keySpaces
+}
+
+// Metadata is the identity as key value pairs for the outgoing metadata.
+func (c ClusterIdentity) Metadata() []string {
+	pairs := []string{ClusterMetadataKey, c.Name}
+	for _, keySpace := range c.KeySpaceStrings() {
+		pairs = append(pairs, KeySpaceMetadataKey, keySpace)
+	}
+	return pairs
+}
+
+func (c ClusterIdentity) String() string {
+	return fmt.Sprintf("cluster %q with key space %s", c.Name, strings.Join(c.KeySpaceStrings(), " and "))
+}
+
+// Matches tells why other is not of the same cluster, if it is not: it must
+// have the same name and share a key space.
+func (c ClusterIdentity) Matches(other ClusterIdentity) error {
+	mismatch := errors.New("cluster mismatch: this node is in " + c.String() + ", the other one in " + other.String())
+	if c.Name != other.Name {
+		return mismatch
+	}
+	for _, mine := range c.KeySpaces {
+		for _, theirs := range other.KeySpaces {
+			if mine == theirs {
+				return nil
+			}
+		}
+	}
+	return mismatch
+}
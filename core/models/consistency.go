@@ -0,0 +1,37 @@
+package models
+
+import (
+	"strconv"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const consistencyReason = "CONSISTENCY_NOT_MET"
+
+// ConsistencyError is the error of a request whose consistency level was not
+// met. It is Unavailable, like a node that cannot be reached, with a detail
+// telling the two apart: a write failing with it was applied on the copies
+// that answered, so it must not be sent again.
+func ConsistencyError(received, needed int) error {
+	st := status.New(codes.Unavailable, "consistency level not met, "+strconv.Itoa(received)+" of the "+strconv.Itoa(needed)+" copies needed answered")
+	detailed, err := st.WithDetails(&errdetails.ErrorInfo{Reason: consistencyReason})
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+func IsConsistencyError(err error) bool {
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != codes.Unavailable {
+		return false
+	}
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok && info.Reason == consistencyReason {
+			return true
+		}
+	}
+	return false
+}
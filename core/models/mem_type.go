@@ -18,3 +18,14 @@ func GetMemTypeFromString(s string) MemType {
 		return NotSupported
 	}
 }
+
+func (t MemType) String() string {
+	switch t {
+	case Mem:
+		return "Mem"
+	case Disk:
+		return "Disk"
+	default:
+		return "NotSupported"
+	}
+}
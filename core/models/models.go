@@ -1,6 +1,51 @@
 package models
 
+import (
+	"strconv"
+	"strings"
+
+	"github.com/raonismaneoto/CustomDHT/commons/helpers"
+)
+
+const VirtualNodeMetadataKey = "dht-vnode"
+
 type NodeRepresentation struct {
 	Id      int64
 	Address string
 }
+
+func (n NodeRepresentation) Host() string {
+	return PhysicalAddress(n.Address)
+}
+
+// VirtualAddress gives every virtual node of a process its own address by
+// suffixing the token. Token 0 keeps the plain address.
+func VirtualAddress(address string, token int) string {
+	if token == 0 {
+		return address
+	}
+	return address + "/" + strconv.Itoa(token)
+}
+
+func PhysicalAddress(address string) string {
+	return strings.SplitN(address, "/", 2)[0]
+}
+
+func VirtualToken(address string) string {
+	parts := strings.SplitN(address, "/", 2)
+	if len(parts) < 2 {
+		return "0"
+	}
+	return parts[1]
+}
+
+// KeySpace is how keys are placed on the ring: its size, 2^M, and the hash
+// algorithm turning keys into positions.
+type KeySpace struct {
+	M    int
+	Hash string
+}
+
+func (k KeySpace) Position(key string) int64 {
+	return helpers.GetKeyPosition(key, k.M, k.Hash)
+}
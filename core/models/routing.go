@@ -0,0 +1,34 @@
+package models
+
+import (
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const routingReason = "ROUTING_FAILED"
+
+// RoutingError is the error of a lookup the ring could not route, a loop or
+// too many hops. It carries a detail telling it apart from other errors of
+// the same code, which gRPC, the storage or a quota give as well.
+func RoutingError(code codes.Code, message string) error {
+	st := status.New(code, message)
+	detailed, err := st.WithDetails(&errdetails.ErrorInfo{Reason: routingReason})
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+func IsRoutingError(err error) bool {
+	st, ok := status.FromError(err)
+	if !ok {
+		return false
+	}
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok && info.Reason == routingReason {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,231 @@
+package node
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/raonismaneoto/CustomDHT/commons/grpc_api"
+	"github.com/raonismaneoto/CustomDHT/core/models"
+	"github.com/raonismaneoto/CustomDHT/core/ring"
+)
+
+// walks stop there, a broken ring may not lead back to where they started
+const maxCheckedNodes = 1 << 12
+
+// ringView is what the checker learns of one node.
+type ringView struct {
+	node        models.NodeRepresentation
+	successor   models.NodeRepresentation
+	predecessor models.NodeRepresentation
+	fingers     []models.NodeRepresentation
+}
+
+func (n *Node) Fingers() []models.NodeRepresentation {
+	return n.chord.fingers()
+}
+
+func (n *Node) viewOf(node models.NodeRepresentation) (ringView, error) {
+	view := ringView{node: node}
+	// the other calls retry for long against a node that is down
+	if _, err := n.client.Ping(node.Address); err != nil {
+		return view, err
+	}
+	succ, err := n.client.Successor(node.Address)
+	if err != nil {
+		return view, err
+	}
+	view.successor = models.NodeRepresentation{Id: succ.Id, Address: succ.Endpoint}
+	if pred, err := n.client.Predecessor(node.Address); err == nil {
+		view.predecessor = models.NodeRepresentation{Id: pred.Id, Address: pred.Endpoint}
+	}
+	fingers, err := n.client.Fingers(node.Address)
+	if err != nil {
+		return view, err
+	}
+	for _, finger := range fingers.Fingers {
+		view.fingers = append(view.fingers, models.NodeRepresentation{Id: finger.Id, Address: finger.Endpoint})
+	}
+	return view, nil
+}
+
+// CheckRing walks the ring through the successors from this node, checking
+// that every node is the predecessor of the next one, that the ids go up
+// but for a single wrap around and that every finger points to the
+// successor of its start. Live nodes referred to along the way but off the
+// ring are reported as orphans, or as split rings when their successors go
+// around without reaching it.
+func (n *Node) CheckRing() *grpc_api.CheckRingResponse {
+	report := &grpc_api.CheckRingResponse{}
+	n.router.checkRing(report)
+	report.Consistent = len(report.Problems) == 0
+	return report
+}
+
+func (c *chordRouter) checkRing(report *grpc_api.CheckRingResponse) {
+	n := c.n
+	problem := func(format string, args ...interface{}) {
+		report.Problems = append(report.Problems, fmt.Sprintf(format, args...))
+	}
+
+	var views []ringView
+	onRing := make(map[string]bool)
+	closed := false
+	current := n.self()
+	for len(views) < maxCheckedNodes {
+		view, err := n.viewOf(current)
+		if err != nil {
+			report.Unreachable++
+			problem("%s is unreachable: %v", current.Address, err)
+			break
+		}
+		onRing[current.Address] = true
+		views = append(views, view)
+		report.Ring = append(report.Ring, &grpc_api.NodeInfo{Id: current.Id, Endpoint: current.Address})
+
+		next := view.successor
+		if next.Address == n.address {
+			closed = true
+			break
+		}
+		if next.Address == "" {
+			problem("%s has no successor", current.Address)
+			break
+		}
+		if onRing[next.Address] {
+			problem("the successors of %s lead back to %s instead of %s", current.Address, next.Address, n.address)
+			break
+		}
+		current = next
+	}
+
+	wraps := 0
+	for i, view := range views {
+		var previous ringView
+		if i > 0 {
+			previous = views[i-1]
+		} else if closed {
+			previous = views[len(views)-1]
+		} else {
+			continue
+		}
+		if view.predecessor.Address != previous.node.Address {
+			report.BadPredecessors++
+			problem("the predecessor of %s is %q, expected %s", view.node.Address, view.predecessor.Address, previous.node.Address)
+		}
+		if view.node.Id <= previous.node.Id {
+			wraps++
+			if wraps > 1 {
+				report.BadOrder++
+				problem("ids go down again from %s (%d) to %s (%d)", previous.node.Address, previous.node.Id, view.node.Address, view.node.Id)
+			}
+		}
+	}
+
+	if closed {
+		n.checkFingers(views, report, problem)
+	}
+	n.checkOffRing(views, onRing, report, problem)
+}
+
+// checkFingers compares the fingers of every node with the successors of
+// their starts among the nodes on the ring. With proximity neighbour
+// selection any node of the finger interval is right.
+func (n *Node) checkFingers(views []ringView, report *grpc_api.CheckRingResponse, problem func(string, ...interface{})) {
+	members := make([]models.NodeRepresentation, len(views))
+	onRing := make(map[string]bool)
+	for i, view := range views {
+		members[i] = view.node
+		onRing[view.node.Address] = true
+	}
+	sort.Slice(members, func(i, j int) bool { return members[i].Id < members[j].Id })
+	successorOf := func(key int64) models.NodeRepresentation {
+		i := sort.Search(len(members), func(i int) bool { return members[i].Id >= key })
+		if i == len(members) {
+			i = 0
+		}
+		return members[i]
+	}
+
+	m := n.KeySpace().M
+	for _, view := range views {
+		wrong, first := 0, -1
+		for i, finger := range view.fingers {
+			start := ring.FingerStart(view.node.Id, i, m)
+			if finger.Address == successorOf(start).Address {
+				continue
+			}
+			width := ring.Distance(start, ring.FingerStart(view.node.Id, i+1, m), m)
+			if pnsCandidates() > 1 && onRing[finger.Address] && ring.Distance(start, finger.Id, m) < width {
+				continue
+			}
+			wrong++
+			if first < 0 {
+				first = i
+			}
+		}
+		if wrong > 0 {
+			start := ring.FingerStart(view.node.Id, first, m)
+			report.BadFingers += int32(wrong)
+			problem("%s has %d wrong fingers, finger %d points to %q instead of %s", view.node.Address, wrong, first, view.fingers[first].Address, successorOf(start).Address)
+		}
+	}
+}
+
+// checkOffRing follows the live nodes the ring refers to without them being
+// on it, fingers, predecessors and failure detector members, through their
+// successors.
+func (n *Node) checkOffRing(views []ringView, onRing map[string]bool, report *grpc_api.CheckRingResponse, problem func(string, ...interface{})) {
+	var referred []models.NodeRepresentation
+	for _, view := range views {
+		referred = append(referred, view.predecessor, view.successor)
+		referred = append(referred, view.fingers...)
+	}
+	for _, member := range n.Members() {
+		referred = append(referred, models.NodeRepresentation{Id: member.Id, Address: member.Address})
+	}
+
+	checked := make(map[string]bool)
+	for _, node := range referred {
+		if node.Address == "" || onRing[node.Address] || checked[node.Address] {
+			continue
+		}
+		checked[node.Address] = true
+		if _, err := n.client.Ping(node.Address); err != nil {
+			continue
+		}
+
+		chain := []models.NodeRepresentation{node}
+		inChain := map[string]bool{node.Address: true}
+		current := node
+		for len(chain) < maxCheckedNodes {
+			view, err := n.viewOf(current)
+			if err != nil || view.successor.Address == "" {
+				break
+			}
+			next := view.successor
+			if onRing[next.Address] {
+				for _, orphan := range chain {
+					report.Orphans++
+					problem("%s is not on the ring, its successors lead into it at %s", orphan.Address, next.Address)
+				}
+				break
+			}
+			if inChain[next.Address] {
+				report.SplitRings++
+				var addresses []string
+				for _, node := range chain {
+					addresses = append(addresses, node.Address)
+				}
+				problem("split ring of %d nodes: %v", len(chain), addresses)
+				break
+			}
+			if checked[next.Address] {
+				break
+			}
+			checked[next.Address] = true
+			inChain[next.Address] = true
+			chain = append(chain, next)
+			current = next
+		}
+	}
+}
@@ -0,0 +1,709 @@
+package node
+
+import (
+	"errors"
+	"log"
+	"strconv"
+	"sync"
+
+	"github.com/raonismaneoto/CustomDHT/commons/grpc_api"
+	"github.com/raonismaneoto/CustomDHT/commons/helpers"
+	"github.com/raonismaneoto/CustomDHT/core/models"
+	"github.com/raonismaneoto/CustomDHT/core/ring"
+	"github.com/raonismaneoto/CustomDHT/core/router"
+)
+
+// errNotOnRing turns the calls keeping a Chord ring down on the nodes of
+// other overlays.
+var errNotOnRing = errors.New("the node is not on a chord ring")
+
+// predecessorPings is how many pings in a row a predecessor the failure
+// detector does not watch has to miss before its range is taken over.
+const predecessorPings = 3
+
+func newRouter(n *Node) overlay {
+	if router.Overlay() == router.KademliaOverlay {
+		return &kademliaRouter{Kademlia: router.NewKademlia(n.self(), n.M, n.client), n: n}
+	}
+	n.chord = &chordRouter{n: n, fingerTable: make([]models.NodeRepresentation, n.M, n.M)}
+	return n.chord
+}
+
+// chordRouter is the Chord ring the node keeps itself, fingers, successor
+// list and predecessor, along with the maintenance keeping it up to date.
+// mu guards the ring, the maintenance loops, the failure detector and the
+// calls of the other nodes all changing it. It is never held over a remote
+// call, the node being called back meanwhile.
+type chordRouter struct {
+	n               *Node
+	mu              sync.RWMutex
+	fingerTable     []models.NodeRepresentation
+	predecessor     models.NodeRepresentation
+	predPredecessor models.NodeRepresentation
+	successorList   []models.NodeRepresentation
+	nextFinger      int
+	// the pings missed in a row by missedPredecessor
+	missedPredecessor models.NodeRepresentation
+	missedPings       int
+}
+
+func (c *chordRouter) Name() string {
+	return router.ChordOverlay
+}
+
+func (c *chordRouter) Join(seed models.NodeRepresentation) error {
+	c.mu.Lock()
+	c.fingerTable = make([]models.NodeRepresentation, c.n.M, c.n.M)
+	c.predecessor = models.NodeRepresentation{}
+	c.successorList = nil
+	c.mu.Unlock()
+	return c.join(&seed)
+}
+
+func (c *chordRouter) Start() {
+	n := c.n
+	n.startMembership()
+
+	helpers.PeriodicInvocation(c.stabilize, stabilizeInterval())
+	helpers.PeriodicInvocation(c.checkSucc, checkSuccInterval())
+	helpers.PeriodicInvocation(c.checkPredecessor, checkPredecessorInterval())
+	helpers.PeriodicInvocation(c.fixFingers, fixFingersInterval())
+	if len(helpers.OrderedNamespaces()) > 0 {
+		helpers.PeriodicInvocation(n.balanceLoad, loadBalanceInterval())
+	}
+	helpers.PeriodicInvocation(n.checkPartition, mergeInterval())
+	helpers.PeriodicInvocation(n.rehome, mergeInterval())
+	if isOneHop() {
+		go n.walkRing()
+		helpers.PeriodicInvocation(n.walkRing, oneHopInterval())
+	}
+}
+
+func (c *chordRouter) Stop() {
+	if c.n.members != nil {
+		c.n.members.Stop()
+	}
+}
+
+func (c *chordRouter) Owner(key int64) (models.NodeRepresentation, int32, error) {
+	response, err := c.n.owner(key, Route{})
+	if err != nil {
+		return models.NodeRepresentation{}, 0, err
+	}
+	return models.NodeRepresentation{Id: response.OwnerNodeId, Address: response.OwnerNodeEndpoint}, response.Hops, nil
+}
+
+// IsResponsible tells whether key lies in (predecessor, id], the range this
+// node is responsible for.
+func (c *chordRouter) IsResponsible(key int64) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.predecessor.Address == "" {
+		// only a node alone in the ring owns everything, one that has just
+		// joined owns nothing until notified by its predecessor
+		return !c.isFingerSet(0) || c.fingerTable[0].Address == c.n.address
+	}
+
+	return ring.InHalfOpenInterval(key, c.predecessor.Id, c.n.id)
+}
+
+func (c *chordRouter) OwnedRange() (int64, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.predecessor.Id, c.predecessor.Address != ""
+}
+
+func (c *chordRouter) ReplicaTargets(key int64, count int) []models.NodeRepresentation {
+	return c.n.chordReplicaTargets(count)
+}
+
+func (c *chordRouter) Closest(key int64, count int, from models.NodeRepresentation) []models.NodeRepresentation {
+	owner, candidates, done := c.closestPreceding(key, count)
+	if done {
+		return []models.NodeRepresentation{owner}
+	}
+	return candidates
+}
+
+func (c *chordRouter) Members() []models.NodeRepresentation {
+	n := c.n
+	pred, _ := c.neighbours()
+	seen := map[string]bool{n.address: true}
+	var members []models.NodeRepresentation
+	for _, node := range append(append([]models.NodeRepresentation{pred}, c.successors()...), c.fingers()...) {
+		if node.Address != "" && !seen[node.Address] {
+			seen[node.Address] = true
+			members = append(members, node)
+		}
+	}
+	return members
+}
+
+// The ring is read through the methods below, a node on another overlay
+// having no ring to read.
+
+// neighbours are the predecessor and the successor of the node.
+func (c *chordRouter) neighbours() (models.NodeRepresentation, models.NodeRepresentation) {
+	if c == nil {
+		return models.NodeRepresentation{}, models.NodeRepresentation{}
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if !c.isFingerSet(0) {
+		return c.predecessor, models.NodeRepresentation{}
+	}
+	return c.predecessor, c.fingerTable[0]
+}
+
+func (c *chordRouter) successor() models.NodeRepresentation {
+	_, succ := c.neighbours()
+	return succ
+}
+
+// successors is the successor followed by the next nodes of the ring, as
+// last learned from the successor.
+func (c *chordRouter) successors() []models.NodeRepresentation {
+	if c == nil {
+		return nil
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if !c.isFingerSet(0) {
+		return nil
+	}
+	if len(c.successorList) == 0 || c.successorList[0].Address != c.fingerTable[0].Address {
+		return []models.NodeRepresentation{c.fingerTable[0]}
+	}
+	return append([]models.NodeRepresentation{}, c.successorList...)
+}
+
+func (c *chordRouter) fingers() []models.NodeRepresentation {
+	if c == nil {
+		return nil
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return append([]models.NodeRepresentation{}, c.fingerTable...)
+}
+
+// nextHop is the node a lookup of key goes to from this one, done telling
+// that it is the successor and owns key.
+func (c *chordRouter) nextHop(key int64) (models.NodeRepresentation, bool) {
+	if c == nil {
+		return models.NodeRepresentation{}, false
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	self := models.NodeRepresentation{Id: c.n.id, Address: c.n.address}
+	return ring.NextHop(self, c.fingerTable[0], c.fingerTable, key)
+}
+
+// isFingerSet expects the ring to be locked.
+func (c *chordRouter) isFingerSet(index int) bool {
+	return index < len(c.fingerTable) && c.fingerTable[index].Address != ""
+}
+
+// replaceSuccessor makes next the successor in place of previous, unless the
+// successor changed meanwhile.
+func (c *chordRouter) replaceSuccessor(previous, next models.NodeRepresentation) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.isFingerSet(0) || c.fingerTable[0].Address != previous.Address {
+		return false
+	}
+	c.fingerTable[0] = next
+	return true
+}
+
+func (c *chordRouter) setId(id int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.n.id = id
+}
+
+// moveId moves the node from previous to id, unless the id changed
+// meanwhile.
+func (c *chordRouter) moveId(previous, id int64) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.n.id != previous {
+		return false
+	}
+	c.n.id = id
+	return true
+}
+
+// routeOwner forwards the owner lookup of key towards its owner, one hop
+// nodes sending it straight there.
+func (c *chordRouter) routeOwner(key int64, route Route) (*grpc_api.OwnerResponse, error) {
+	n := c.n
+	if owner, ok := n.oneHopOwner(key); ok {
+		return &grpc_api.OwnerResponse{
+			OwnerNodeId:       owner.Id,
+			OwnerNodeEndpoint: owner.Address,
+			Hops:              route.Hops,
+		}, nil
+	}
+
+	nextHop, done := c.nextHop(key)
+	if done {
+		// the successor owns what follows this node
+		return &grpc_api.OwnerResponse{
+			OwnerNodeId:       nextHop.Id,
+			OwnerNodeEndpoint: nextHop.Address,
+			Hops:              route.Hops,
+			RangeStart:        n.Id(),
+			RangeKnown:        true,
+		}, nil
+	}
+
+	next, err := n.forward(route)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Println("going to forward the owner lookup to: " + nextHop.Address)
+	return n.client.ForwardOwner(nextHop.Address, next.ownerRequest(key))
+}
+
+// routeQuery forwards the query of key towards its owner, reading the
+// replicas in the successor list when the successor owning it is down.
+func (c *chordRouter) routeQuery(key int64, route Route) (*grpc_api.QueryResponse, error) {
+	n := c.n
+	if owner, ok := n.oneHopOwner(key); ok && !n.isSuspected(owner.Address) {
+		next, err := n.forward(route)
+		if err != nil {
+			return &grpc_api.QueryResponse{}, err
+		}
+		response, err := n.client.ForwardQuery(owner.Address, next.queryRequest(key))
+		if err == nil {
+			return response, nil
+		}
+		// the ring routing gets around an owner the table has wrong
+		log.Println("one hop query to " + owner.Address + " failed: " + err.Error())
+		n.forget(owner.Address)
+	}
+
+	nextHop, done := c.nextHop(key)
+	if nextHop.Address != "" && nextHop.Address != n.address {
+		// a successor owning the key that is suspected is not waited for
+		if done && n.isSuspected(nextHop.Address) {
+			if replica, ok := n.successorReplicas(key, route); ok {
+				return replica, nil
+			}
+		}
+		next, err := n.forward(route)
+		if err != nil {
+			return &grpc_api.QueryResponse{}, err
+		}
+		log.Println("key not found in node, going to forward the query to:")
+		log.Println("nodeAddress: " + nextHop.Address)
+		response, err := n.client.ForwardQuery(nextHop.Address, next.queryRequest(key))
+		if err == nil {
+			n.learnOwner(key, models.NodeRepresentation{Id: response.ResponsibleNodeId, Address: response.ResponsibleNodeEndpoint}, response.RangeStart, response.RangeKnown)
+		} else if done {
+			if replica, ok := n.successorReplicas(key, route); ok {
+				return replica, nil
+			}
+		}
+		return response, err
+	}
+
+	log.Println("unable to query for key" + strconv.FormatInt(key, 10))
+	return &grpc_api.QueryResponse{}, errors.New("unable to query for key " + strconv.FormatInt(key, 10))
+}
+
+// join only finds the successor of the node. The predecessor, and the
+// predecessor of the successor, are settled by stabilize and notify, so
+// nodes joining between the same pair at the same time still end up in
+// order.
+func (c *chordRouter) join(partner *models.NodeRepresentation) error {
+	log.Println("Checking node id collisions")
+	if err := c.n.resolveIdCollision(partner); err != nil {
+		return err
+	}
+	log.Println("Starting finger table")
+	if err := c.startFingerTable(partner); err != nil {
+		return err
+	}
+	c.stabilize()
+	return nil
+}
+
+func (c *chordRouter) startFingerTable(partner *models.NodeRepresentation) error {
+	n := c.n
+	log.Println("querying succ info in startFingerTable")
+	id, m := n.position()
+	succInfo, err := n.client.Owner(partner.Address, id)
+	if err != nil {
+		return err
+	}
+	fingerTable := make([]models.NodeRepresentation, m, m)
+	fingerTable[0] = models.NodeRepresentation{Id: succInfo.OwnerNodeId, Address: succInfo.OwnerNodeEndpoint}
+	c.mu.Lock()
+	c.fingerTable[0] = fingerTable[0]
+	c.mu.Unlock()
+	c.updateSuccessorList()
+
+	for i := 1; i < m; i++ {
+		start := ring.FingerStart(id, i, m)
+		// the previous finger already covers this start, so it is the
+		// successor of it as well
+		if ring.InHalfOpenInterval(start, id, fingerTable[i-1].Id) {
+			fingerTable[i] = fingerTable[i-1]
+			continue
+		}
+
+		currNodeInfo, err := n.client.Owner(partner.Address, start)
+		if err != nil {
+			log.Println(err.Error())
+			continue
+		}
+		fingerTable[i] = models.NodeRepresentation{Id: currNodeInfo.OwnerNodeId, Address: currNodeInfo.OwnerNodeEndpoint}
+	}
+
+	c.mu.Lock()
+	copy(c.fingerTable[1:], fingerTable[1:])
+	c.mu.Unlock()
+	return nil
+}
+
+// stabilize is Chord's periodic check of the successor: a node that joined
+// between this node and its successor becomes the new successor, and the
+// successor is notified in case this node is its predecessor.
+func (c *chordRouter) stabilize() {
+	n := c.n
+	if n.left.Load() {
+		return
+	}
+	c.mu.Lock()
+	if !c.isFingerSet(0) {
+		// the first node of the ring learns its successor from the first
+		// node notifying it
+		if c.predecessor.Address == "" {
+			c.mu.Unlock()
+			return
+		}
+		c.fingerTable[0] = c.predecessor
+	}
+	succ := c.fingerTable[0]
+	c.mu.Unlock()
+
+	// a dead successor is left to checkSucc
+	if _, err := n.client.Ping(succ.Address); err != nil {
+		return
+	}
+
+	predecessor, err := n.client.Predecessor(succ.Address)
+	if err == nil && predecessor.Endpoint != n.address && ring.InOpenInterval(predecessor.Id, n.Id(), succ.Id) {
+		log.Println("stabilize found a new successor: " + predecessor.Endpoint)
+		c.replaceSuccessor(succ, models.NodeRepresentation{Id: predecessor.Id, Address: predecessor.Endpoint})
+	}
+
+	succ = c.successor()
+	if _, err := n.client.Notify(succ.Address, n.self()); err != nil {
+		log.Println("unable to notify " + succ.Address + ": " + err.Error())
+	}
+	c.updateSuccessorList()
+	n.placeReplicas()
+}
+
+// notify takes candidate as predecessor when there is none yet, when it lies
+// between the current one and this node, or when the current one is gone.
+func (c *chordRouter) notify(candidate models.NodeRepresentation) bool {
+	n := c.n
+	if n.left.Load() || candidate.Address == "" || candidate.Address == n.address {
+		return false
+	}
+	previous, _ := c.neighbours()
+	if previous == candidate {
+		return false
+	}
+	if previous.Address != "" && previous.Address != candidate.Address && !ring.InOpenInterval(candidate.Id, previous.Id, n.Id()) && !n.isDead(previous.Address) {
+		if _, err := n.client.Ping(previous.Address); err == nil {
+			return false
+		}
+	}
+
+	c.mu.Lock()
+	// another node took the place meanwhile, candidate notifies again
+	if c.predecessor != previous {
+		c.mu.Unlock()
+		return false
+	}
+	c.predecessor = candidate
+	succ, id := c.fingerTable[0], n.id
+	joined := previous.Address == "" && c.isFingerSet(0) && succ.Address != n.address
+	c.mu.Unlock()
+
+	log.Println("new predecessor: " + candidate.Address)
+	n.track(candidate)
+	n.learn(candidate)
+	if joined {
+		// the node has just joined, its keys were held by the successor
+		go func() {
+			n.pullKeys(succ.Address, candidate.Id, id)
+			n.replicateRange(candidate.Id, id)
+		}()
+	}
+	n.replicateGrowth(previous, candidate)
+
+	return true
+}
+
+// checkSucc fails over to the first live entry of the successor list when
+// the successor is gone, so the ring survives successorListLength()-1
+// failures in a row.
+func (c *chordRouter) checkSucc() {
+	n := c.n
+	succ := c.successor()
+	if n.left.Load() || succ.Address == "" {
+		return
+	}
+
+	_, err := n.client.Ping(succ.Address)
+	if err == nil {
+		c.updateSuccessorList()
+		return
+	}
+
+	log.Println("successor " + succ.Address + " is unreachable, going to fail over")
+	for _, candidate := range c.successors() {
+		if candidate.Address == succ.Address || n.isDead(candidate.Address) {
+			continue
+		}
+		if _, err := n.client.Ping(candidate.Address); err != nil {
+			continue
+		}
+
+		response, err := n.client.HandleNewPredecessor(candidate.Address, n.self())
+		if err != nil || !response.Ok {
+			log.Println("handle new predecessor failed for " + candidate.Address)
+			continue
+		}
+
+		if c.replaceSuccessor(succ, candidate) {
+			c.updateSuccessorList()
+			n.placeReplicas()
+		}
+		return
+	}
+
+	log.Println("there is no live node left in the successor list")
+	c.fallBackSuccessor(succ)
+}
+
+func (c *chordRouter) updateSuccessorList() {
+	n := c.n
+	succ := c.successor()
+	if succ.Address == "" {
+		return
+	}
+	list := []models.NodeRepresentation{succ}
+	if succ.Address != n.address {
+		response, err := n.client.SuccessorList(succ.Address)
+		if err != nil {
+			log.Println("unable to get the successor list of " + succ.Address + ": " + err.Error())
+			return
+		}
+		for _, entry := range response.Successors {
+			// the list wrapped around the whole ring
+			if len(list) >= successorListLength() || entry.Endpoint == "" || entry.Endpoint == n.address {
+				break
+			}
+			list = append(list, models.NodeRepresentation{Id: entry.Id, Address: entry.Endpoint})
+		}
+	}
+
+	c.mu.Lock()
+	// a list of a former successor is left behind
+	if c.isFingerSet(0) && c.fingerTable[0].Address == succ.Address {
+		c.successorList = list
+	}
+	c.mu.Unlock()
+	n.track(list...)
+}
+
+// checkPredecessor takes the range of a dead predecessor over, and while it
+// is alive keeps track of its own predecessor, which is where the range of
+// this node starts once it dies. A predecessor the failure detector watches
+// is dead once it says so, any other once it misses predecessorPings pings
+// in a row.
+func (c *chordRouter) checkPredecessor() {
+	n := c.n
+	pred, _ := c.neighbours()
+	if n.left.Load() || pred.Address == "" {
+		return
+	}
+	if n.isDead(pred.Address) {
+		log.Println("predecessor " + pred.Address + " is dead, going to take its range over")
+		c.takeOver(pred)
+		return
+	}
+	if _, err := n.client.Ping(pred.Address); err != nil {
+		if n.isWatched(pred.Address) || !c.missPing(pred) {
+			return
+		}
+		log.Println("predecessor " + pred.Address + " missed " + strconv.Itoa(predecessorPings) + " pings, going to take its range over")
+		c.takeOver(pred)
+		return
+	}
+
+	predOfPred, err := n.client.Predecessor(pred.Address)
+	c.mu.Lock()
+	c.missedPredecessor, c.missedPings = models.NodeRepresentation{}, 0
+	if err == nil && c.predecessor == pred {
+		c.predPredecessor = models.NodeRepresentation{Id: predOfPred.Id, Address: predOfPred.Endpoint}
+	}
+	c.mu.Unlock()
+}
+
+// missPing counts a ping pred missed and tells whether it missed enough of
+// them in a row to be given up for dead.
+func (c *chordRouter) missPing(pred models.NodeRepresentation) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.missedPredecessor != pred {
+		c.missedPredecessor, c.missedPings = pred, 0
+	}
+	c.missedPings++
+	return c.missedPings >= predecessorPings
+}
+
+// takeOver extends the range of the node back to the predecessor of the dead
+// one. Being its successor, this node already holds replicas of the keys of
+// the dead node, the copies its other replicas hold being pulled in case
+// they are more recent.
+func (c *chordRouter) takeOver(dead models.NodeRepresentation) {
+	n := c.n
+	c.mu.RLock()
+	next := c.predPredecessor
+	c.mu.RUnlock()
+	nextDead := next.Address != "" && n.isDead(next.Address)
+
+	c.mu.Lock()
+	if c.predecessor.Address != dead.Address {
+		c.mu.Unlock()
+		return
+	}
+	c.predPredecessor = models.NodeRepresentation{}
+
+	if next.Address == n.address {
+		c.predecessor = models.NodeRepresentation{}
+		if c.isFingerSet(0) && c.fingerTable[0].Address == dead.Address {
+			c.fingerTable[0] = models.NodeRepresentation{Id: n.id, Address: n.address}
+			c.successorList = nil
+		}
+		c.mu.Unlock()
+		log.Println("predecessor " + dead.Address + " was the only other node, this one owns the whole ring now")
+		return
+	}
+	if next.Address == "" || next.Address == dead.Address || nextDead {
+		c.predecessor = models.NodeRepresentation{}
+		c.mu.Unlock()
+		log.Println("predecessor " + dead.Address + " is dead and its predecessor is unknown, waiting for the next one to notify")
+		return
+	}
+
+	c.predecessor = next
+	succ := c.fingerTable[0]
+	c.mu.Unlock()
+
+	log.Println("taking over the range (" + strconv.FormatInt(next.Id, 10) + ", " + strconv.FormatInt(dead.Id, 10) + "] of " + dead.Address)
+	n.track(next)
+	go func() {
+		// the new predecessor would otherwise keep routing to the dead node
+		// until it checks its successor
+		if _, err := n.client.HandleNewSuccessor(next.Address, n.self(), succ); err != nil {
+			log.Println("unable to link " + next.Address + " to this node: " + err.Error())
+		}
+		for _, replica := range n.walkReplicas(replicationFactor() - 2) {
+			n.pullKeys(replica.Address, next.Id, dead.Id)
+		}
+		n.replicateRange(next.Id, dead.Id)
+	}()
+}
+
+// fixFingers refreshes one finger per call, going around the table, so
+// finger i ends up pointing to the successor of id + 2^i. The successor
+// itself, finger 0, is kept by the join and checkSucc.
+func (c *chordRouter) fixFingers() {
+	n := c.n
+	c.mu.Lock()
+	if n.left.Load() || !c.isFingerSet(0) || len(c.fingerTable) < 2 {
+		c.mu.Unlock()
+		return
+	}
+	c.nextFinger++
+	if c.nextFinger >= len(c.fingerTable) {
+		c.nextFinger = 1
+	}
+	i, start := c.nextFinger, ring.FingerStart(n.id, c.nextFinger, n.M)
+	c.mu.Unlock()
+
+	owner, err := n.Owner(start)
+	if err != nil {
+		log.Println(err.Error())
+		return
+	}
+	finger := n.proximateFinger(i, models.NodeRepresentation{Id: owner.OwnerNodeId, Address: owner.OwnerNodeEndpoint})
+	c.mu.Lock()
+	changed := false
+	if i < len(c.fingerTable) {
+		changed = c.fingerTable[i].Address != finger.Address
+		c.fingerTable[i] = finger
+	}
+	c.mu.Unlock()
+	if changed {
+		log.Println("finger " + strconv.Itoa(i) + " now points to " + finger.Address)
+	}
+	n.track(finger)
+}
+
+func (c *chordRouter) handleNewSuccessor(newSucc models.NodeRepresentation, nNSucc models.NodeRepresentation) error {
+	n := c.n
+	succ := c.successor()
+	id, m := n.position()
+	// a successor moving its own id is not replacing anyone
+	if succ.Address != "" && succ.Address != newSucc.Address && ring.Distance(id, newSucc.Id, m) > ring.Distance(id, succ.Id, m) {
+		log.Println("ping current succ")
+		_, err := n.client.Ping(succ.Address)
+		if err == nil {
+			return errors.New("invalid successor")
+		}
+	}
+
+	c.mu.Lock()
+	c.fingerTable[0] = newSucc
+	c.successorList = []models.NodeRepresentation{newSucc}
+	if nNSucc.Address != "" && nNSucc.Id != n.id {
+		c.successorList = append(c.successorList, nNSucc)
+	}
+	c.mu.Unlock()
+	go func() {
+		c.updateSuccessorList()
+		n.placeReplicas()
+	}()
+
+	return nil
+}
+
+func (c *chordRouter) handleNewPredecessor(nPred models.NodeRepresentation) error {
+	n := c.n
+	pred, _ := c.neighbours()
+	id, m := n.position()
+	if pred.Address != "" && ring.Distance(id, nPred.Id, m) < ring.Distance(id, pred.Id, m) {
+		_, err := n.client.Ping(pred.Address)
+		if err == nil {
+			return errors.New("invalid predecessor")
+		}
+	}
+
+	c.mu.Lock()
+	previous := c.predecessor
+	c.predecessor = nPred
+	c.mu.Unlock()
+	n.replicateGrowth(previous, nPred)
+
+	return nil
+}
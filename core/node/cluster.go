@@ -0,0 +1,52 @@
+package node
+
+import (
+	"log"
+	"os"
+
+	"github.com/raonismaneoto/CustomDHT/core/models"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ClusterName is the name of the cluster the node belongs to, nodes only
+// taking in nodes of the same one.
+func ClusterName() string {
+	if name := os.Getenv("CLUSTER_NAME"); name != "" {
+		return name
+	}
+	return "customdht"
+}
+
+func (n *Node) Identity() models.ClusterIdentity {
+	identity := models.ClusterIdentity{Name: ClusterName(), KeySpaces: []models.KeySpace{n.KeySpace()}}
+	// nodes the migration has not reached yet are still in the previous one
+	if current := n.currentMigration(); current != nil {
+		identity.KeySpaces = append(identity.KeySpaces, current.previous)
+	}
+	return identity
+}
+
+// CheckIdentity turns other down, with a permission denied status, unless it
+// is of the cluster of this node.
+func (n *Node) CheckIdentity(other models.ClusterIdentity) error {
+	if err := n.Identity().Matches(other); err != nil {
+		log.Println("rejecting a call: " + err.Error())
+		return status.Error(codes.PermissionDenied, err.Error())
+	}
+	return nil
+}
+
+// handshake makes sure seed is of the cluster of this node before joining
+// through it, both ends checking the identity of the other.
+func (n *Node) handshake(seed string) error {
+	response, err := n.client.Handshake(seed, n.Identity())
+	if err != nil {
+		return err
+	}
+	theirs, err := models.ParseClusterIdentity(response.Name, response.KeySpaces)
+	if err != nil {
+		return err
+	}
+	return n.Identity().Matches(theirs)
+}
@@ -0,0 +1,206 @@
+package node
+
+import (
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/raonismaneoto/CustomDHT/commons/grpc_api"
+	"github.com/raonismaneoto/CustomDHT/commons/helpers"
+	"github.com/raonismaneoto/CustomDHT/core/models"
+	"github.com/raonismaneoto/CustomDHT/core/storage"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// The consistency level of a request is how many copies of the key, out of
+// the replication factor, the owner waits for: writes are acknowledged by
+// that many, reads answered with the most recent of that many. Reads and
+// writes overlapping, R + W above the factor, see each other. Deletes leave
+// a tombstone behind, so the value a replica that missed one still holds
+// loses to it.
+const (
+	ConsistencyOne    = "ONE"
+	ConsistencyQuorum = "QUORUM"
+	ConsistencyAll    = "ALL"
+)
+
+// Acks is the number of copies level asks for, ONE when it is empty. A
+// number asks for that many copies.
+func Acks(level string) (int, error) {
+	factor := replicationFactor()
+	switch strings.ToUpper(level) {
+	case "", ConsistencyOne:
+		return 1, nil
+	case ConsistencyQuorum:
+		return factor/2 + 1, nil
+	case ConsistencyAll:
+		return factor, nil
+	}
+	acks, err := strconv.Atoi(level)
+	if err != nil || acks < 1 || acks > factor {
+		return 0, status.Error(codes.InvalidArgument, "invalid consistency level "+level+", expected ONE, QUORUM, ALL or a number of copies up to "+strconv.Itoa(factor))
+	}
+	return acks, nil
+}
+
+// replicateAcked is replicate waiting for needed of the replicas to
+// acknowledge. The others are still sent to once it returns.
+func (n *Node) replicateAcked(write copyWrite, needed int) error {
+	targets := n.replicaTargets(write.Key)
+	results := make(chan error, len(targets))
+	for _, target := range targets {
+		go func(address string) {
+			err := n.writeCopy(address, write, true)
+			if err != nil {
+				n.replicationFailed(address, write, err)
+			}
+			results <- err
+		}(target.Address)
+	}
+
+	acked, failed := 0, 0
+	for acked < needed && acked+failed < len(targets) {
+		if err := <-results; err != nil {
+			failed++
+		} else {
+			acked++
+		}
+	}
+	if acked < needed {
+		return models.ConsistencyError(acked+1, needed+1)
+	}
+	return nil
+}
+
+// saveOwned stores a key this node owns and copies it to the replicas, the
+// first acks of the copies acknowledging before it returns.
+func (n *Node) saveOwned(key int64, name string, value []byte, acks int) error {
+	err := n.storage.Save(storage.Entry{Key: key, Data: value, Name: name})
+	if err != nil {
+		log.Println(err.Error())
+		return err
+	}
+	write := copyWrite{Key: key, Name: name, Data: value, Version: n.storage.Version(key)}
+	if acks <= 1 {
+		n.replicateLater(write)
+		return nil
+	}
+	return n.replicateAcked(write, acks-1)
+}
+
+// tombstoneWindow is how long, in seconds, the delete of a key is kept,
+// TOMBSTONE_WINDOW. It outlives the hints so a replica the delete missed
+// gets it replayed or repaired before it is forgotten.
+func tombstoneWindow() time.Duration {
+	return time.Duration(helpers.EnvInt("TOMBSTONE_WINDOW", 1, 24*60*60)) * time.Second
+}
+
+func (n *Node) expireTombstones() {
+	n.storage.ExpireTombstones(time.Now().Add(-tombstoneWindow()).UnixNano())
+}
+
+// deleteOwned is saveOwned for deletes.
+func (n *Node) deleteOwned(key int64, acks int) error {
+	// the replicas may hold a copy the owner does not
+	version := time.Now().UnixNano()
+	if _, err := n.storage.Remove(key, version); err != nil {
+		log.Println(err.Error())
+		return err
+	}
+	write := copyWrite{Key: key, Deleted: true, Version: version}
+	if acks <= 1 {
+		n.replicateLater(write)
+		return nil
+	}
+	return n.replicateAcked(write, acks-1)
+}
+
+type copyRead struct {
+	address  string
+	response *grpc_api.QueryResponse
+}
+
+// readOwned reads a key this node owns from acks of its copies, this one
+// included, answering with the most recent. The copies found older than it
+// are brought up to date.
+func (n *Node) readOwned(key int64, acks int) (*grpc_api.QueryResponse, error) {
+	local := n.QueryLocal(key, "")
+	if acks <= 1 {
+		return &local, nil
+	}
+
+	targets := n.replicaTargets(key)
+	results := make(chan copyRead, len(targets))
+	for _, target := range targets {
+		go func(address string) {
+			results <- copyRead{address: address, response: n.client.QueryLocal(address, key, "")}
+		}(target.Address)
+	}
+
+	read := []copyRead{{address: n.address, response: &local}}
+	for pending := len(targets); len(read) < acks && pending > 0; pending-- {
+		// a copy that did not answer has no endpoint
+		if result := <-results; result.response.ResponsibleNodeEndpoint != "" {
+			read = append(read, result)
+		}
+	}
+	if len(read) < acks {
+		return nil, models.ConsistencyError(len(read), acks)
+	}
+
+	newest := newestCopy(read)
+	n.repair(key, newest, read)
+	newest.ResponsibleNodeEndpoint, newest.ResponsibleNodeId = n.address, n.Id()
+	return newest, nil
+}
+
+// newestCopy is the most recent of the copies read, a delete being newer
+// than the values written before it.
+func newestCopy(read []copyRead) *grpc_api.QueryResponse {
+	newest := read[0].response
+	for _, r := range read[1:] {
+		if r.response.Version > newest.Version {
+			newest = r.response
+		}
+	}
+	return newest
+}
+
+// repair writes newest, a value or a delete, over the copies read that are
+// older.
+func (n *Node) repair(key int64, newest *grpc_api.QueryResponse, read []copyRead) {
+	if newest.Version == 0 {
+		return
+	}
+	deleted := len(newest.Data) == 0
+	name, _ := n.storage.Name(key)
+	for _, r := range read {
+		if r.response.Version >= newest.Version {
+			continue
+		}
+		log.Println("repairing the copy of key " + strconv.FormatInt(key, 10) + " at " + r.address)
+		if r.address == n.address {
+			var err error
+			if deleted {
+				_, err = n.storage.Remove(key, newest.Version)
+			} else {
+				_, err = n.storage.PutIfNewer(storage.Entry{Key: key, Data: newest.Data, Name: name, Version: newest.Version})
+			}
+			if err != nil {
+				log.Println(err.Error())
+			}
+			continue
+		}
+		go func(address string) {
+			write := copyWrite{Key: key, Name: name, Data: newest.Data, Version: newest.Version, Replace: true}
+			if deleted {
+				write = copyWrite{Key: key, Deleted: true, Version: newest.Version}
+			}
+			if err := n.writeCopy(address, write, false); err != nil {
+				n.replicationFailed(address, write, err)
+			}
+		}(r.address)
+	}
+}
@@ -0,0 +1,313 @@
+package node
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/raonismaneoto/CustomDHT/commons/helpers"
+	"github.com/raonismaneoto/CustomDHT/core/metrics"
+	"github.com/raonismaneoto/CustomDHT/core/models"
+)
+
+// hints are the copy writes replicas missed while unreachable, kept by the
+// node that coordinated them until the replica is back. A hint puts the
+// whole value the owner holds over the copy, so the latest one of a key
+// stands for all the writes the replica missed. They are kept in an append
+// only log, compacted once most of it is dropped hints.
+type hints struct {
+	mu        sync.Mutex
+	pending   map[string]map[int64]hint
+	replaying map[string]bool
+	log       *os.File
+	records   int
+}
+
+type hint struct {
+	Write  copyWrite
+	Stored int64
+}
+
+// hintRecord is a line of the hints log, storing Hint for Address or, when
+// there is none, dropping the hint of Key stored at Stored.
+type hintRecord struct {
+	Address string
+	Hint    *hint `json:",omitempty"`
+	Key     int64
+	Stored  int64
+}
+
+// hintWindow is how long, in seconds, a hint is kept for a replica that
+// does not come back, HINT_WINDOW. The replica catches up on the writes
+// dropped with it through read repair and the next placement of replicas.
+func hintWindow() time.Duration {
+	return time.Duration(helpers.EnvInt("HINT_WINDOW", 1, 3*60*60)) * time.Second
+}
+
+func hintReplayInterval() int {
+	return helpers.EnvInt("HINT_REPLAY_INTERVAL", 1, 10)
+}
+
+// HintsFilePath is where the node at address logs its hints, HINTS_FILE or
+// hints in dir.
+func HintsFilePath(dir string, address string) string {
+	path := os.Getenv("HINTS_FILE")
+	if path == "" {
+		path = filepath.Join(dir, "hints")
+	}
+	if token := models.VirtualToken(address); token != "0" {
+		path += "-" + token
+	}
+	return path
+}
+
+// loadHints takes back the hints logged before a restart.
+func (n *Node) loadHints() {
+	n.hints.mu.Lock()
+	defer n.hints.mu.Unlock()
+	n.hints.pending = make(map[string]map[int64]hint)
+	n.hints.replaying = make(map[string]bool)
+
+	if file, err := os.Open(HintsFilePath(n.storage.Dir(), n.address)); err == nil {
+		reader := bufio.NewReader(file)
+		for {
+			line, err := reader.ReadBytes('\n')
+			var record hintRecord
+			// a line cut short by a crash is the last one, and is skipped
+			if len(line) > 0 && json.Unmarshal(line, &record) == nil {
+				n.applyHint(record)
+			}
+			if err != nil {
+				if err != io.EOF {
+					log.Println("unable to read the hints log: " + err.Error())
+				}
+				break
+			}
+		}
+		file.Close()
+	}
+	for _, writes := range n.hints.pending {
+		metrics.Add(metrics.HintsPending, int64(len(writes)))
+	}
+	n.compactHints()
+}
+
+// applyHint applies record to the pending hints, which it expects to be
+// locked.
+func (n *Node) applyHint(record hintRecord) bool {
+	writes := n.hints.pending[record.Address]
+	if record.Hint != nil {
+		if writes == nil {
+			writes = make(map[int64]hint)
+			n.hints.pending[record.Address] = writes
+		}
+		_, held := writes[record.Hint.Write.Key]
+		writes[record.Hint.Write.Key] = *record.Hint
+		return !held
+	}
+	if current, ok := writes[record.Key]; !ok || current.Stored != record.Stored {
+		return false
+	}
+	delete(writes, record.Key)
+	if len(writes) == 0 {
+		delete(n.hints.pending, record.Address)
+	}
+	return true
+}
+
+// logHint appends record to the hints log, which it expects to be locked.
+func (n *Node) logHint(record hintRecord) {
+	if n.hints.log == nil {
+		return
+	}
+	line, err := json.Marshal(record)
+	if err != nil {
+		log.Println("unable to encode a hint: " + err.Error())
+		return
+	}
+	if _, err := n.hints.log.Write(append(line, '\n')); err != nil {
+		log.Println("unable to log a hint: " + err.Error())
+		return
+	}
+	n.hints.records++
+}
+
+// compactHints writes the log again with the pending hints alone, which it
+// expects to be locked.
+func (n *Node) compactHints() {
+	path := HintsFilePath(n.storage.Dir(), n.address)
+	file, err := os.Create(path + ".tmp")
+	if err != nil {
+		log.Println("unable to compact the hints log: " + err.Error())
+		return
+	}
+	writer := bufio.NewWriter(file)
+	records := 0
+	for address, writes := range n.hints.pending {
+		for _, h := range writes {
+			h := h
+			line, err := json.Marshal(hintRecord{Address: address, Hint: &h})
+			if err != nil {
+				continue
+			}
+			writer.Write(append(line, '\n'))
+			records++
+		}
+	}
+	err = writer.Flush()
+	file.Close()
+	if err == nil {
+		err = os.Rename(path+".tmp", path)
+	}
+	if err != nil {
+		log.Println("unable to compact the hints log: " + err.Error())
+		return
+	}
+
+	if n.hints.log != nil {
+		n.hints.log.Close()
+	}
+	n.hints.log, err = os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		log.Println("unable to open the hints log: " + err.Error())
+	}
+	n.hints.records = records
+}
+
+// storeHint keeps write for the replica at address, which missed it. Short
+// of a delete, the hint is the whole value this node holds, put over the
+// copy when replayed.
+func (n *Node) storeHint(address string, write copyWrite) {
+	if !write.Deleted && !write.Replace {
+		data, err := n.storage.Read(write.Key)
+		if err != nil {
+			return
+		}
+		write.Data, write.Version, write.Replace = data, n.storage.Version(write.Key), true
+	}
+
+	n.hints.mu.Lock()
+	defer n.hints.mu.Unlock()
+	if n.hints.pending == nil {
+		return
+	}
+	record := hintRecord{Address: address, Hint: &hint{Write: write, Stored: time.Now().UnixNano()}}
+	if n.applyHint(record) {
+		metrics.Inc(metrics.HintsPending)
+	}
+	n.logHint(record)
+	metrics.Inc(metrics.HintsStored)
+}
+
+// dropHint forgets the hint of key for address, unless a later write took
+// its place meanwhile. It expects the hints to be locked.
+func (n *Node) dropHint(address string, sent hint) {
+	record := hintRecord{Address: address, Key: sent.Write.Key, Stored: sent.Stored}
+	if n.applyHint(record) {
+		n.logHint(record)
+		metrics.Add(metrics.HintsPending, -1)
+	}
+}
+
+// replayHints sends the hints kept for address over to it, stopping at the
+// first it fails to take. The expired ones are dropped instead.
+func (n *Node) replayHints(address string) {
+	n.hints.mu.Lock()
+	if len(n.hints.pending[address]) == 0 || n.hints.replaying[address] {
+		n.hints.mu.Unlock()
+		return
+	}
+	n.hints.replaying[address] = true
+	var held []hint
+	for _, h := range n.hints.pending[address] {
+		held = append(held, h)
+	}
+	n.hints.mu.Unlock()
+
+	replayed, expired := 0, 0
+	var sent []hint
+	for _, h := range held {
+		if time.Since(time.Unix(0, h.Stored)) > hintWindow() {
+			expired++
+			sent = append(sent, h)
+			continue
+		}
+		if err := n.writeCopy(address, h.Write, false); err != nil {
+			log.Println("unable to replay the hints for " + address + ": " + err.Error())
+			break
+		}
+		replayed++
+		sent = append(sent, h)
+	}
+
+	n.hints.mu.Lock()
+	for _, h := range sent {
+		n.dropHint(address, h)
+	}
+	n.hints.replaying[address] = false
+	n.hints.mu.Unlock()
+
+	metrics.Add(metrics.HintsReplayed, int64(replayed))
+	metrics.Add(metrics.HintsExpired, int64(expired))
+	if replayed > 0 || expired > 0 {
+		log.Println("replayed " + strconv.Itoa(replayed) + " hints to " + address + ", " + strconv.Itoa(expired) + " expired")
+	}
+}
+
+// replayAllHints replays the hints of the replicas not suspected to be down
+// and drops the expired ones of the others, covering replicas the failure
+// detector does not watch. The log is compacted on the way.
+func (n *Node) replayAllHints() {
+	n.hints.mu.Lock()
+	var addresses []string
+	pending := 0
+	for address, writes := range n.hints.pending {
+		addresses = append(addresses, address)
+		pending += len(writes)
+	}
+	if n.hints.records > 2*pending+64 {
+		n.compactHints()
+	}
+	n.hints.mu.Unlock()
+
+	for _, address := range addresses {
+		if n.isSuspected(address) {
+			n.expireHints(address)
+			continue
+		}
+		n.replayHints(address)
+	}
+}
+
+func (n *Node) expireHints(address string) {
+	n.hints.mu.Lock()
+	defer n.hints.mu.Unlock()
+	expired := 0
+	for _, h := range n.hints.pending[address] {
+		if time.Since(time.Unix(0, h.Stored)) > hintWindow() {
+			n.dropHint(address, h)
+			expired++
+		}
+	}
+	if expired > 0 {
+		metrics.Add(metrics.HintsExpired, int64(expired))
+		log.Println(strconv.Itoa(expired) + " hints for " + address + " expired")
+	}
+}
+
+// PendingHints is how many hints this node keeps for each replica.
+func (n *Node) PendingHints() map[string]int64 {
+	n.hints.mu.Lock()
+	defer n.hints.mu.Unlock()
+	pending := make(map[string]int64, len(n.hints.pending))
+	for address, writes := range n.hints.pending {
+		pending[address] = int64(len(writes))
+	}
+	return pending
+}
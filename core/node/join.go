@@ -0,0 +1,88 @@
+package node
+
+import (
+	"errors"
+	"log"
+
+	"github.com/cenkalti/backoff"
+	"github.com/raonismaneoto/CustomDHT/core/models"
+)
+
+const (
+	HealthStarting = "starting"
+	HealthJoining  = "joining"
+	HealthReady    = "ready"
+	HealthLeft     = "left"
+)
+
+type Health struct {
+	Status    string
+	Attempts  int
+	Seed      string
+	LastError string
+}
+
+func (n *Node) Health() Health {
+	n.healthMu.Lock()
+	defer n.healthMu.Unlock()
+	health := n.health
+	if health.Status == "" {
+		health.Status = HealthStarting
+	}
+	return health
+}
+
+func (n *Node) setHealth(status string) {
+	n.healthMu.Lock()
+	defer n.healthMu.Unlock()
+	n.health.Status = status
+	if status == HealthReady {
+		n.health.LastError = ""
+	}
+}
+
+// joinWithRetry tries the seeds in order until one of them takes the node
+// in, backing off between rounds. It only returns once the node is in.
+func (n *Node) joinWithRetry(seeds []string) {
+	n.setHealth(HealthJoining)
+	b := backoff.NewExponentialBackOff()
+	b.MaxElapsedTime = 0
+
+	backoff.Retry(func() error {
+		for _, seed := range seeds {
+			if seed == n.address {
+				continue
+			}
+			n.healthMu.Lock()
+			n.health.Attempts++
+			n.health.Seed = seed
+			n.healthMu.Unlock()
+
+			// a seed that is down would hold the join up for the whole
+			// retry budget of the lookups
+			if _, err := n.client.Ping(seed); err != nil {
+				n.joinFailed("seed "+seed+" is unreachable", err)
+				continue
+			}
+			if err := n.handshake(seed); err != nil {
+				n.joinFailed("handshake with seed "+seed+" failed", err)
+				continue
+			}
+
+			err := n.router.Join(models.NodeRepresentation{Address: seed})
+			if err == nil {
+				return nil
+			}
+
+			n.joinFailed("unable to join through "+seed, err)
+		}
+		return errors.New("no seed took the node in")
+	}, b)
+}
+
+func (n *Node) joinFailed(reason string, err error) {
+	log.Println(reason + ": " + err.Error())
+	n.healthMu.Lock()
+	defer n.healthMu.Unlock()
+	n.health.LastError = reason + ": " + err.Error()
+}
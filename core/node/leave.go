@@ -0,0 +1,170 @@
+package node
+
+import (
+	"errors"
+	"log"
+	"strconv"
+
+	"github.com/raonismaneoto/CustomDHT/commons/grpc_api"
+	"github.com/raonismaneoto/CustomDHT/core/models"
+	"github.com/raonismaneoto/CustomDHT/core/storage"
+)
+
+// Leave takes the node out of the overlay, handing its keys off first. The
+// node takes no part in the overlay afterwards.
+func (n *Node) Leave() error {
+	if n.left.Load() {
+		return nil
+	}
+	return n.router.Leave()
+}
+
+// Leave takes the node out of the ring. The owned keys go to the successor
+// first, then the predecessor and the successor are linked to each other,
+// and at last every replica goes back to the owner of its key to be placed
+// again.
+func (c *chordRouter) Leave() error {
+	n := c.n
+	pred, succ := c.neighbours()
+	if succ.Address == "" || succ.Address == n.address {
+		log.Println("leaving a ring this node is alone in")
+		n.left.Store(true)
+		n.setHealth(HealthLeft)
+		c.Stop()
+		return nil
+	}
+
+	var owned, replicas []*grpc_api.HandoffEntry
+	for _, key := range n.storage.Keys() {
+		data, err := n.storage.Read(key)
+		if err != nil {
+			continue
+		}
+		name, _ := n.storage.Name(key)
+		entry := &grpc_api.HandoffEntry{Key: key, Data: data, Name: name, Version: n.storage.Version(key)}
+		if n.mustKeyBeInNode(key) {
+			owned = append(owned, entry)
+		} else if n.localOwner(key) == nil {
+			entry.Replica = true
+			replicas = append(replicas, entry)
+		}
+	}
+
+	log.Println("leaving the ring, handing " + strconv.Itoa(len(owned)) + " keys off to " + succ.Address)
+	if err := n.client.Handoff(succ.Address, owned); err != nil {
+		log.Println("unable to hand the owned keys off: " + err.Error())
+		return err
+	}
+
+	n.left.Store(true)
+	n.setHealth(HealthLeft)
+	c.Stop()
+	if _, err := n.client.HandleLeave(succ.Address, n.self(), pred, succ); err != nil {
+		log.Println("unable to link the successor to the predecessor: " + err.Error())
+	}
+	if pred.Address != "" {
+		if _, err := n.client.HandleLeave(pred.Address, n.self(), pred, succ); err != nil {
+			log.Println("unable to link the predecessor to the successor: " + err.Error())
+		}
+	}
+
+	byOwner := make(map[string][]*grpc_api.HandoffEntry)
+	for _, entry := range replicas {
+		owner, err := n.client.Owner(succ.Address, entry.Key)
+		if err != nil {
+			log.Println("unable to find the owner of replica " + strconv.FormatInt(entry.Key, 10) + ": " + err.Error())
+			continue
+		}
+		if owner.OwnerNodeEndpoint == n.address {
+			continue
+		}
+		byOwner[owner.OwnerNodeEndpoint] = append(byOwner[owner.OwnerNodeEndpoint], entry)
+	}
+	failed := 0
+	for address, entries := range byOwner {
+		log.Println("handing " + strconv.Itoa(len(entries)) + " replicas back to " + address)
+		if err := n.client.Handoff(address, entries); err != nil {
+			log.Println("unable to hand replicas back to " + address + ": " + err.Error())
+			failed += len(entries)
+		}
+	}
+	if failed > 0 {
+		return errors.New(strconv.Itoa(failed) + " replicas could not be handed back")
+	}
+
+	log.Println("left the ring")
+	return nil
+}
+
+// HandleLeave links this node past a neighbour leaving the ring.
+func (n *Node) HandleLeave(leaving, predecessor, successor models.NodeRepresentation) {
+	n.forget(leaving.Address)
+	n.owners.Invalidate(leaving.Address)
+	if n.chord != nil {
+		n.chord.handleLeave(leaving, predecessor, successor)
+	}
+}
+
+func (c *chordRouter) handleLeave(leaving, predecessor, successor models.NodeRepresentation) {
+	n := c.n
+	c.mu.Lock()
+	predLeft := c.predecessor.Address == leaving.Address
+	if predLeft {
+		if predecessor.Address == n.address {
+			predecessor = models.NodeRepresentation{}
+		}
+		c.predecessor = predecessor
+	}
+	succLeft := c.isFingerSet(0) && c.fingerTable[0].Address == leaving.Address
+	if succLeft {
+		if successor.Address == n.address {
+			successor = models.NodeRepresentation{}
+		}
+		c.fingerTable[0] = successor
+		c.successorList = nil
+	}
+	for i := 1; i < len(c.fingerTable); i++ {
+		if c.fingerTable[i].Address == leaving.Address {
+			c.fingerTable[i] = successor
+		}
+	}
+	c.mu.Unlock()
+
+	if predLeft {
+		log.Println("predecessor " + leaving.Address + " left, new predecessor: " + predecessor.Address)
+		// the keys the leaving node handed off need replicas here as well
+		go n.replicateRange(predecessor.Id, leaving.Id)
+	}
+	if succLeft {
+		log.Println("successor " + leaving.Address + " left, new successor: " + successor.Address)
+		go c.updateSuccessorList()
+	}
+}
+
+// TakeHandoff stores an entry handed off by another node, unless the copy
+// here is more recent. The replicas are then placed again from the copy kept.
+func (n *Node) TakeHandoff(entry storage.Entry, replica bool) error {
+	taken, err := n.storage.PutIfNewer(entry)
+	if err != nil || !replica {
+		return err
+	}
+
+	if !taken {
+		if entry.Data, err = n.storage.Read(entry.Key); err != nil {
+			return err
+		}
+	}
+	n.replicateEntry(entry.Key, entry.Name, entry.Data)
+	return nil
+}
+
+func (n *Node) replicateRange(start, end int64) {
+	for _, key := range n.Keys(start, end) {
+		data, err := n.storage.Read(key)
+		if err != nil {
+			continue
+		}
+		name, _ := n.storage.Name(key)
+		n.replicateEntry(key, name, data)
+	}
+}
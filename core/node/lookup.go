@@ -0,0 +1,259 @@
+package node
+
+import (
+	"errors"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/raonismaneoto/CustomDHT/commons/grpc_api"
+	"github.com/raonismaneoto/CustomDHT/commons/helpers"
+	"github.com/raonismaneoto/CustomDHT/core/metrics"
+	"github.com/raonismaneoto/CustomDHT/core/models"
+	"github.com/raonismaneoto/CustomDHT/core/ring"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	RecursiveLookup = "recursive"
+	IterativeLookup = "iterative"
+	// OneHopLookup has every node keep the whole ring and send lookups
+	// straight to the owner
+	OneHopLookup = "onehop"
+)
+
+// routing errors travel back to the origin as they are and are not retried
+// by the client, a broken ring does not heal within a retry. Each has a code
+// of its own, and a detail for the nodes on the way back to tell them from
+// other errors.
+var (
+	ErrRoutingLoop = models.RoutingError(codes.Aborted, "routing loop")
+	ErrTooManyHops = models.RoutingError(codes.ResourceExhausted, "too many hops")
+	// ErrNotOwner turns a direct request down, the sender having the owner
+	// of the key wrong
+	ErrNotOwner = status.Error(codes.FailedPrecondition, "not the owner of the key")
+)
+
+// isRoutingError tells the routing errors apart by their detail, the ones
+// coming back from other nodes not being the same values.
+func isRoutingError(err error) bool {
+	return models.IsRoutingError(err)
+}
+
+// Route is what a recursive lookup carries from hop to hop, Consistency
+// being the level the owner reads at.
+type Route struct {
+	Trace       bool
+	Hops        int32
+	Visited     []string
+	Consistency string
+}
+
+type lookupStep struct {
+	from     models.NodeRepresentation
+	response *grpc_api.ClosestPrecedingResponse
+	err      error
+	elapsed  time.Duration
+}
+
+func lookupMode() string {
+	switch mode := os.Getenv("LOOKUP_MODE"); mode {
+	case IterativeLookup, OneHopLookup:
+		return mode
+	}
+	return RecursiveLookup
+}
+
+func lookupParallelism() int {
+	return helpers.EnvInt("LOOKUP_PARALLELISM", 1, 3)
+}
+
+func lookupHopTimeout() time.Duration {
+	return time.Millisecond * time.Duration(helpers.EnvInt("LOOKUP_HOP_TIMEOUT", 1, 2000))
+}
+
+func maxHops(m int) int32 {
+	return int32(helpers.EnvInt("MAX_HOPS", 1, 2*m))
+}
+
+// forward returns the route the next hop gets, failing when this node was
+// already visited or the lookup has gone through too many nodes.
+func (n *Node) forward(route Route) (Route, error) {
+	for _, address := range route.Visited {
+		if address == n.address {
+			log.Println("routing loop, " + n.address + " was already visited")
+			metrics.Inc(metrics.RoutingLoops)
+			return route, ErrRoutingLoop
+		}
+	}
+	if route.Hops >= maxHops(n.KeySpace().M) {
+		log.Println("lookup dropped after " + strconv.Itoa(int(route.Hops)) + " hops")
+		metrics.Inc(metrics.TooManyHops)
+		return route, ErrTooManyHops
+	}
+	visited := append(append([]string{}, route.Visited...), n.address)
+	return Route{Trace: route.Trace, Hops: route.Hops + 1, Visited: visited, Consistency: route.Consistency}, nil
+}
+
+func (r Route) ownerRequest(key int64) *grpc_api.OwnerRequest {
+	return &grpc_api.OwnerRequest{Key: key, Trace: r.Trace, Hops: r.Hops, Visited: r.Visited}
+}
+
+func (r Route) queryRequest(key int64) *grpc_api.QueryRequest {
+	return &grpc_api.QueryRequest{Key: key, Trace: r.Trace, Hops: r.Hops, Visited: r.Visited, Consistency: r.Consistency}
+}
+
+// IsIterative tells whether a lookup has to be driven by the receiving node,
+// either because the request asked for it or because of the node config.
+func IsIterative(requested bool) bool {
+	return requested || lookupMode() == IterativeLookup
+}
+
+// ClosestPreceding is what an iterative lookup asks of each hop. When done
+// is true owner holds key, otherwise candidates are up to count known nodes
+// preceding key, the closest first.
+func (n *Node) ClosestPreceding(key int64, count int) (models.NodeRepresentation, []models.NodeRepresentation, bool) {
+	if n.chord == nil {
+		// the other overlays answer FindNode instead
+		return models.NodeRepresentation{}, nil, false
+	}
+	return n.chord.closestPreceding(key, count)
+}
+
+func (c *chordRouter) closestPreceding(key int64, count int) (models.NodeRepresentation, []models.NodeRepresentation, bool) {
+	n := c.n
+	if count < 1 {
+		count = 1
+	}
+
+	if n.mustKeyBeInNode(key) {
+		return n.self(), nil, true
+	}
+
+	if sibling := n.localOwner(key); sibling != nil {
+		return sibling.self(), nil, true
+	}
+
+	next, done := c.nextHop(key)
+	if done {
+		return next, nil, true
+	}
+
+	known := append(c.fingers(), c.successors()...)
+	return models.NodeRepresentation{}, ring.ClosestPrecedingN(n.self(), known, key, count, n.KeySpace().M), false
+}
+
+// IterativeOwner resolves the owner of key from this node instead of
+// forwarding the lookup. Every round asks the closest candidates not yet
+// queried, up to LOOKUP_PARALLELISM at once, and a candidate that does not
+// answer within LOOKUP_HOP_TIMEOUT is just left behind. The returned path
+// starts with this node and has one hop per candidate asked, failed ones
+// included.
+func (n *Node) IterativeOwner(key int64) (models.NodeRepresentation, []*grpc_api.Hop, error) {
+	return n.router.iterativeOwner(key)
+}
+
+func (c *chordRouter) iterativeOwner(key int64) (models.NodeRepresentation, []*grpc_api.Hop, error) {
+	n := c.n
+	start := time.Now()
+	alpha := lookupParallelism()
+	path := []*grpc_api.Hop{n.hop(start)}
+	owner, candidates, done := c.closestPreceding(key, alpha)
+	if done {
+		return owner, path, nil
+	}
+
+	timeout := lookupHopTimeout()
+	queried := map[string]bool{n.address: true}
+	m := n.KeySpace().M
+
+	for round := 0; round < 2*m; round++ {
+		var batch []models.NodeRepresentation
+		for _, candidate := range candidates {
+			if len(batch) == alpha {
+				break
+			}
+			if !queried[candidate.Address] {
+				batch = append(batch, candidate)
+			}
+		}
+		if len(batch) == 0 {
+			return models.NodeRepresentation{}, path, errors.New("iterative lookup ran out of candidates")
+		}
+
+		steps := make(chan lookupStep, len(batch))
+		for _, candidate := range batch {
+			queried[candidate.Address] = true
+			go func(candidate models.NodeRepresentation) {
+				sent := time.Now()
+				response, err := n.client.ClosestPreceding(candidate.Address, key, alpha, timeout)
+				steps <- lookupStep{from: candidate, response: response, err: err, elapsed: time.Since(sent)}
+			}(candidate)
+		}
+
+		for range batch {
+			step := <-steps
+			hop := &grpc_api.Hop{Id: step.from.Id, Endpoint: step.from.Address, ElapsedMicros: step.elapsed.Microseconds()}
+			path = append(path, hop)
+			if step.err != nil {
+				log.Println("iterative lookup hop " + step.from.Address + " failed: " + step.err.Error())
+				hop.Error = step.err.Error()
+				continue
+			}
+			if step.response.Done {
+				path[0].ElapsedMicros = time.Since(start).Microseconds()
+				n.observeLookup(start, int32(round+1))
+				return models.NodeRepresentation{Id: step.response.Owner.Id, Address: step.response.Owner.Endpoint}, path, nil
+			}
+			for _, candidate := range step.response.Candidates {
+				candidates = append(candidates, models.NodeRepresentation{Id: candidate.Id, Address: candidate.Endpoint})
+			}
+		}
+
+		sort.SliceStable(candidates, func(i, j int) bool {
+			return ring.Distance(candidates[i].Id, key, m) < ring.Distance(candidates[j].Id, key, m)
+		})
+	}
+
+	return models.NodeRepresentation{}, path, errors.New("iterative lookup exceeded the hop limit")
+}
+
+// IterativeQuery reads key from the owner IterativeOwner finds, the read
+// being the last hop of the path.
+func (n *Node) IterativeQuery(key int64, consistency string) (*grpc_api.QueryResponse, error) {
+	acks, err := Acks(consistency)
+	if err != nil {
+		return nil, err
+	}
+	owner, path, err := n.IterativeOwner(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if owner.Address == n.address || n.localOwner(key) != nil {
+		response, err := n.RoutedQuery(key, Route{Trace: true, Consistency: consistency})
+		if err != nil {
+			return nil, err
+		}
+		response.Path = append(path, response.Path...)
+		return response, nil
+	}
+
+	log.Println("iterative lookup resolved key " + strconv.FormatInt(key, 10) + " to " + owner.Address)
+	sent := time.Now()
+	var response *grpc_api.QueryResponse
+	if acks > 1 {
+		// the owner reads the copies of its replicas along with its own
+		response, err = n.client.ForwardQuery(owner.Address, &grpc_api.QueryRequest{Key: key, Direct: true, Consistency: consistency})
+	} else {
+		response, err = n.client.ReadLocal(owner.Address, key, "")
+	}
+	if err != nil {
+		return nil, err
+	}
+	response.Path = append(path, &grpc_api.Hop{Id: owner.Id, Endpoint: owner.Address, ElapsedMicros: time.Since(sent).Microseconds()})
+	return response, nil
+}
@@ -0,0 +1,126 @@
+package node
+
+import (
+	"github.com/raonismaneoto/CustomDHT/commons/grpc_api"
+	"github.com/raonismaneoto/CustomDHT/core/membership"
+	"github.com/raonismaneoto/CustomDHT/core/models"
+)
+
+// startMembership hands the nodes this one points to over to the failure
+// detector, which from then on reports the ones that die.
+func (n *Node) startMembership() {
+	n.members = membership.New(n.self(), n.client, membership.ConfigFromEnv(), n.memberChanged)
+	pred, _ := n.chord.neighbours()
+	n.track(pred)
+	n.track(n.SuccessorList()...)
+	n.track(n.Fingers()...)
+	n.members.Start()
+}
+
+func (n *Node) track(nodes ...models.NodeRepresentation) {
+	for _, node := range nodes {
+		n.remember(node)
+		if n.members != nil {
+			n.members.Add(node)
+		}
+	}
+}
+
+// isDead tells whether the failure detector gave address up for dead.
+func (n *Node) isDead(address string) bool {
+	if n.members == nil {
+		return false
+	}
+	state, known := n.members.State(address)
+	return known && state == membership.Dead
+}
+
+// isWatched tells whether the failure detector keeps track of address.
+func (n *Node) isWatched(address string) bool {
+	if n.members == nil {
+		return false
+	}
+	_, known := n.members.State(address)
+	return known
+}
+
+// isSuspected tells whether the failure detector suspects address, or gave
+// it up for dead already.
+func (n *Node) isSuspected(address string) bool {
+	if n.members == nil {
+		return false
+	}
+	state, known := n.members.State(address)
+	return known && state != membership.Alive
+}
+
+func (n *Node) Probe(request *grpc_api.ProbeRequest) *grpc_api.ProbeResponse {
+	if n.members == nil {
+		return &grpc_api.ProbeResponse{Ack: true}
+	}
+	return n.members.Handle(request)
+}
+
+func (n *Node) Members() []membership.Member {
+	if n.members == nil {
+		return nil
+	}
+	return n.members.Members()
+}
+
+func (n *Node) memberChanged(member membership.Member) {
+	if member.State == membership.Alive {
+		n.replayHints(member.Address)
+		return
+	}
+	if member.State != membership.Dead || n.left.Load() {
+		return
+	}
+	dead := member.Address
+	n.forget(dead)
+	n.owners.Invalidate(dead)
+
+	n.chord.memberDied(dead)
+}
+
+// memberDied takes dead out of the ring, replacing the predecessor and the
+// successor the way checkPredecessor and checkSucc do it.
+func (c *chordRouter) memberDied(dead string) {
+	c.mu.Lock()
+	pred := c.predecessor
+	succDied := c.isFingerSet(0) && c.fingerTable[0].Address == dead
+	if !succDied && len(c.successorList) > 0 {
+		list := make([]models.NodeRepresentation, 0, len(c.successorList))
+		for _, succ := range c.successorList {
+			if succ.Address != dead {
+				list = append(list, succ)
+			}
+		}
+		c.successorList = list
+	}
+	c.mu.Unlock()
+
+	if pred.Address == dead {
+		c.takeOver(pred)
+	}
+	if succDied {
+		c.checkSucc()
+	}
+
+	// a dead finger is replaced by the next live one, fixFingers takes it
+	// to the right node later
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i := len(c.fingerTable) - 1; i >= 1; i-- {
+		if c.fingerTable[i].Address != dead {
+			continue
+		}
+		c.fingerTable[i] = c.fingerTable[0]
+		for j := i + 1; j < len(c.fingerTable); j++ {
+			if c.isFingerSet(j) && c.fingerTable[j].Address != dead {
+				c.fingerTable[i] = c.fingerTable[j]
+				break
+			}
+		}
+	}
+}
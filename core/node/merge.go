@@ -0,0 +1,313 @@
+package node
+
+import (
+	"log"
+	"math/rand"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/raonismaneoto/CustomDHT/commons/grpc_api"
+	"github.com/raonismaneoto/CustomDHT/commons/helpers"
+	"github.com/raonismaneoto/CustomDHT/core/metrics"
+	"github.com/raonismaneoto/CustomDHT/core/models"
+	"github.com/raonismaneoto/CustomDHT/core/ring"
+)
+
+// history is every node this one has pointed to since it started. After a
+// partition heals the nodes of the other side are only found through it.
+type history struct {
+	mu    sync.Mutex
+	nodes map[string]models.NodeRepresentation
+}
+
+func mergeInterval() int {
+	return helpers.EnvInt("MERGE_INTERVAL", 1, 30)
+}
+
+// mergeProbes is how many remembered nodes are asked for each check.
+func mergeProbes() int {
+	return helpers.EnvInt("MERGE_PROBES", 1, 3)
+}
+
+func (n *Node) remember(node models.NodeRepresentation) {
+	if node.Address == "" || node.Address == n.address {
+		return
+	}
+	n.history.mu.Lock()
+	defer n.history.mu.Unlock()
+	if n.history.nodes == nil {
+		n.history.nodes = make(map[string]models.NodeRepresentation)
+	}
+	n.history.nodes[node.Address] = node
+}
+
+// fallBackSuccessor takes the closest live node following this one among the
+// fingers and the remembered nodes as successor, once the whole successor
+// list is gone, as it is for a node cut off from its part of the ring.
+// Stabilization then walks it back to the real successor. With no live node
+// known the node becomes its own successor, the ones pointing to it bringing
+// it back in.
+func (c *chordRouter) fallBackSuccessor(failed models.NodeRepresentation) {
+	n := c.n
+	candidates := c.fingers()
+	n.history.mu.Lock()
+	for _, node := range n.history.nodes {
+		candidates = append(candidates, node)
+	}
+	n.history.mu.Unlock()
+	id, m := n.position()
+	sort.Slice(candidates, func(i, j int) bool {
+		return ring.Distance(id, candidates[i].Id, m) < ring.Distance(id, candidates[j].Id, m)
+	})
+
+	tried := map[string]bool{"": true, n.address: true, failed.Address: true}
+	for _, candidate := range candidates {
+		if tried[candidate.Address] || n.isDead(candidate.Address) {
+			continue
+		}
+		tried[candidate.Address] = true
+		if _, err := n.client.Ping(candidate.Address); err != nil {
+			continue
+		}
+		if c.replaceSuccessor(failed, candidate) {
+			log.Println("falling back to " + candidate.Address + " as successor")
+			go c.updateSuccessorList()
+		}
+		return
+	}
+
+	if c.replaceSuccessor(failed, n.self()) {
+		log.Println("no live node is known, this node is its own successor now")
+	}
+}
+
+// checkPartition asks a few remembered nodes, the neighbours aside, who owns
+// the id of this node. A live node answering with another owner, while this
+// ring does not have it as the owner of its own id either, is on another
+// ring, which is then merged with this one.
+func (n *Node) checkPartition() {
+	pred, succ := n.chord.neighbours()
+	if n.left.Load() || succ.Address == "" {
+		return
+	}
+	neighbours := map[string]bool{n.address: true, pred.Address: true}
+	for _, succ := range n.SuccessorList() {
+		neighbours[succ.Address] = true
+	}
+
+	n.history.mu.Lock()
+	var candidates []models.NodeRepresentation
+	for address, node := range n.history.nodes {
+		if !neighbours[address] {
+			candidates = append(candidates, node)
+		}
+	}
+	n.history.mu.Unlock()
+	rand.Shuffle(len(candidates), func(i, j int) { candidates[i], candidates[j] = candidates[j], candidates[i] })
+	if len(candidates) > mergeProbes() {
+		candidates = candidates[:mergeProbes()]
+	}
+
+	for _, other := range candidates {
+		if _, err := n.client.Ping(other.Address); err != nil {
+			continue
+		}
+		theirs, err := n.client.Owner(other.Address, n.Id())
+		if err != nil || theirs.OwnerNodeEndpoint == n.address {
+			continue
+		}
+		ours, err := n.Owner(other.Id)
+		if err != nil || ours.OwnerNodeEndpoint == other.Address {
+			continue
+		}
+
+		log.Println("found a disjoint ring through " + other.Address + ", which has " + theirs.OwnerNodeEndpoint + " owning this node id")
+		metrics.Inc(metrics.PartitionsDetected)
+		// each ring takes a node of the other in, the merges it sets off
+		// then go on until both are interleaved
+		go n.Merge(other, 0)
+		if _, err := n.client.Merge(other.Address, n.self(), 0); err != nil {
+			log.Println("unable to start the merge on " + other.Address + ": " + err.Error())
+		}
+		return
+	}
+}
+
+// Merge takes candidate, a node of another ring, into this one. The node
+// whose successor interval holds it takes it as successor, and its former
+// successor is handed over to the ring of candidate in turn. Every step
+// shortens a successor pointer, so the merges end once both rings are one.
+func (n *Node) Merge(candidate models.NodeRepresentation, hops int32) {
+	if n.chord != nil {
+		n.chord.merge(candidate, hops)
+	}
+}
+
+func (c *chordRouter) merge(candidate models.NodeRepresentation, hops int32) {
+	n := c.n
+	succ := c.successor()
+	id, m := n.position()
+	if n.left.Load() || succ.Address == "" || candidate.Address == "" || candidate.Address == n.address || hops > maxHops(m) {
+		return
+	}
+	if candidate.Address == succ.Address {
+		return
+	}
+
+	if succ.Address == n.address || ring.InOpenInterval(candidate.Id, id, succ.Id) {
+		if _, err := n.client.Ping(candidate.Address); err != nil {
+			return
+		}
+		if !c.replaceSuccessor(succ, candidate) {
+			return
+		}
+		log.Println("merge: " + candidate.Address + " comes between this node and " + succ.Address)
+		metrics.Inc(metrics.RingMerges)
+		n.track(candidate)
+		go c.updateSuccessorList()
+		if _, err := n.client.Notify(candidate.Address, n.self()); err != nil {
+			log.Println("unable to notify " + candidate.Address + ": " + err.Error())
+		}
+		if succ.Address != n.address {
+			if _, err := n.client.Merge(candidate.Address, succ, 0); err != nil {
+				log.Println("unable to hand " + succ.Address + " over to the merge: " + err.Error())
+			}
+		}
+		return
+	}
+
+	next, _ := c.nextHop(candidate.Id)
+	if next.Address == "" || next.Address == n.address {
+		next = succ
+	}
+	if _, err := n.client.Merge(next.Address, candidate, hops+1); err != nil {
+		log.Println("unable to forward the merge to " + next.Address + ": " + err.Error())
+	}
+}
+
+// rehome hands the stored keys this node neither owns nor replicates over
+// to their owners, which keep the most recent copy. Keys written on both
+// sides of a partition meet again that way once the rings are merged. The
+// storage is shared with the virtual nodes of the process, so keys one of
+// them owns or replicates stay. It waits for key space migrations, which
+// leave the keys not moved yet out of place.
+func (n *Node) rehome() {
+	pred, succ := n.chord.neighbours()
+	if n.left.Load() || succ.Address == "" || pred.Address == "" || n.currentMigration() != nil {
+		return
+	}
+
+	type ownerKeys struct {
+		owner      models.NodeRepresentation
+		start      int64
+		rangeKnown bool
+		replica    bool
+		entries    []*grpc_api.HandoffEntry
+	}
+	var owners []*ownerKeys
+	byOwner := make(map[string]*ownerKeys)
+	for _, key := range n.storage.Keys() {
+		if n.mustKeyBeInNode(key) || n.localOwner(key) != nil {
+			continue
+		}
+		var found *ownerKeys
+		for _, o := range owners {
+			if o.rangeKnown && ring.InHalfOpenInterval(key, o.start, o.owner.Id) {
+				found = o
+				break
+			}
+		}
+		if found == nil {
+			owner, err := n.Owner(key)
+			if err != nil || owner.OwnerNodeEndpoint == n.address {
+				continue
+			}
+			if found = byOwner[owner.OwnerNodeEndpoint]; found == nil {
+				node := models.NodeRepresentation{Id: owner.OwnerNodeId, Address: owner.OwnerNodeEndpoint}
+				found = &ownerKeys{owner: node, start: owner.RangeStart, rangeKnown: owner.RangeKnown, replica: n.holdsReplicaOf(node)}
+				byOwner[node.Address] = found
+				owners = append(owners, found)
+			}
+		}
+		if found.replica {
+			continue
+		}
+		data, err := n.storage.Read(key)
+		if err != nil {
+			continue
+		}
+		name, _ := n.storage.Name(key)
+		entry := &grpc_api.HandoffEntry{Key: key, Data: data, Name: name, Replica: true, Version: n.storage.Version(key)}
+		found.entries = append(found.entries, entry)
+	}
+
+	for _, o := range owners {
+		// replicas that diverged from the copy of their owner go back to it
+		// when they are newer
+		if o.replica {
+			if o.rangeKnown {
+				n.pushNewer(o.owner, o.start, o.owner.Id)
+			}
+			continue
+		}
+		if len(o.entries) == 0 {
+			continue
+		}
+		address := o.owner.Address
+		if err := n.client.Handoff(address, o.entries); err != nil {
+			log.Println("unable to rehome keys to " + address + ": " + err.Error())
+			continue
+		}
+		log.Println("rehomed " + strconv.Itoa(len(o.entries)) + " keys to " + address)
+		metrics.Add(metrics.KeysRehomed, int64(len(o.entries)))
+		for _, entry := range o.entries {
+			// a sibling may have taken the key over meanwhile
+			if !n.mustKeyBeInNode(entry.Key) && n.localOwner(entry.Key) == nil {
+				n.storage.Delete(entry.Key)
+			}
+		}
+	}
+}
+
+// pushNewer hands the keys in (start, end] over to owner where the copy
+// here is more recent than the one there, or there is none there.
+func (n *Node) pushNewer(owner models.NodeRepresentation, start, end int64) {
+	if owner.Address == "" || owner.Address == n.address {
+		return
+	}
+	remote, err := n.client.Keys(owner.Address, start, end)
+	if err != nil {
+		log.Println("unable to list the keys of " + owner.Address + ": " + err.Error())
+		return
+	}
+	theirs := make(map[int64]int64)
+	for i, key := range remote.Keys {
+		if i < len(remote.Versions) {
+			theirs[key] = remote.Versions[i]
+		}
+	}
+
+	var entries []*grpc_api.HandoffEntry
+	for _, key := range n.Keys(start, end) {
+		version := n.storage.Version(key)
+		if theirVersion, ok := theirs[key]; ok && theirVersion >= version {
+			continue
+		}
+		data, err := n.storage.Read(key)
+		if err != nil {
+			continue
+		}
+		name, _ := n.storage.Name(key)
+		entries = append(entries, &grpc_api.HandoffEntry{Key: key, Data: data, Name: name, Version: version})
+	}
+	if len(entries) == 0 {
+		return
+	}
+	if err := n.client.Handoff(owner.Address, entries); err != nil {
+		log.Println("unable to push newer keys to " + owner.Address + ": " + err.Error())
+		return
+	}
+	log.Println("pushed " + strconv.Itoa(len(entries)) + " newer keys to " + owner.Address)
+	metrics.Add(metrics.KeysRehomed, int64(len(entries)))
+}
@@ -0,0 +1,445 @@
+package node
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/raonismaneoto/CustomDHT/commons/grpc_api"
+	"github.com/raonismaneoto/CustomDHT/commons/helpers"
+	"github.com/raonismaneoto/CustomDHT/core/models"
+	"github.com/raonismaneoto/CustomDHT/core/storage"
+)
+
+const migrationPollInterval = 30 * time.Second
+
+// migration tracks the move of the keys stored in this node from the
+// previous key space to the current one. Until the whole cluster is done,
+// reads missing in the current key space fall back to the previous one.
+type migration struct {
+	mu           sync.Mutex
+	previous     models.KeySpace
+	pending      int64
+	moved        int64
+	unmigratable int64
+	done         bool
+}
+
+// KeySpaceFilePath is where the key space of the last migration is kept,
+// KEYSPACE_FILE or keyspace in dir.
+func KeySpaceFilePath(dir string) string {
+	path := os.Getenv("KEYSPACE_FILE")
+	if path == "" {
+		return filepath.Join(dir, "keyspace")
+	}
+	return path
+}
+
+// LoadKeySpace returns the key space persisted in dir by the last
+// migration, if any, so a restarted node does not go back to the one in its
+// env.
+func LoadKeySpace(dir string, fallback models.KeySpace) models.KeySpace {
+	content, err := ioutil.ReadFile(KeySpaceFilePath(dir))
+	if err != nil {
+		return fallback
+	}
+
+	var keySpace models.KeySpace
+	if err := json.Unmarshal(content, &keySpace); err != nil {
+		log.Println("unable to load the persisted key space: " + err.Error())
+		return fallback
+	}
+	return keySpace
+}
+
+func persistKeySpace(dir string, keySpace models.KeySpace) {
+	content, err := json.Marshal(keySpace)
+	if err != nil {
+		log.Println("unable to encode the key space: " + err.Error())
+		return
+	}
+	if err := ioutil.WriteFile(KeySpaceFilePath(dir), content, 0644); err != nil {
+		log.Println("unable to persist the key space: " + err.Error())
+	}
+}
+
+func (n *Node) KeySpace() models.KeySpace {
+	if n.chord != nil {
+		n.chord.mu.RLock()
+		defer n.chord.mu.RUnlock()
+	}
+	return models.KeySpace{M: n.M, Hash: n.hashAlgorithm}
+}
+
+func (n *Node) KeyPosition(name string) int64 {
+	return n.KeySpace().Position(name)
+}
+
+// currentMigration is the key space migration in progress, if any.
+func (n *Node) currentMigration() *migration {
+	n.migrationMu.Lock()
+	defer n.migrationMu.Unlock()
+	return n.migration
+}
+
+// Migrate moves the node to the target key space and passes the
+// announcement on to the successor, until it gets back to the origin.
+func (n *Node) Migrate(target models.KeySpace, origin string, finish bool) error {
+	if finish {
+		n.migrationMu.Lock()
+		current := n.migration
+		n.migration = nil
+		n.migrationMu.Unlock()
+		if current == nil {
+			return nil
+		}
+		log.Println("key space migration finished")
+		n.forwardMigration(target, origin, true)
+		return nil
+	}
+
+	if n.chord == nil {
+		return errNotOnRing
+	}
+	if target.M < 1 || target.M > 62 {
+		return errors.New("invalid M for the key space: " + strconv.Itoa(target.M))
+	}
+	if !helpers.IsHashAlgorithmSupported(target.Hash) {
+		return errors.New("unsupported hash algorithm: " + target.Hash)
+	}
+	n.migrationMu.Lock()
+	previous := n.KeySpace()
+	if target == previous {
+		n.migrationMu.Unlock()
+		return nil
+	}
+	if n.migration != nil && !n.migration.isDone() {
+		n.migrationMu.Unlock()
+		return errors.New("there is a key space migration in progress")
+	}
+	current := &migration{previous: previous}
+	n.migration = current
+	n.migrationMu.Unlock()
+
+	log.Println("starting key space migration to M=" + strconv.Itoa(target.M) + ", hash=" + target.Hash)
+	n.adoptKeySpace(previous, target)
+	go n.moveKeys(current)
+
+	if origin == "" {
+		origin = n.address
+		go n.watchMigration(target)
+	}
+	n.forwardMigration(target, origin, false)
+	return nil
+}
+
+func (n *Node) forwardMigration(target models.KeySpace, origin string, finish bool) {
+	succ, err := n.Successor()
+	if err != nil || succ.Address == origin || succ.Address == n.address {
+		return
+	}
+
+	request := &grpc_api.MigrateRequest{M: int32(target.M), HashAlgorithm: target.Hash, Origin: origin, Finish: finish}
+	go func(address string) {
+		if _, err := n.client.Migrate(address, request); err != nil {
+			log.Println("unable to forward the key space migration: " + err.Error())
+		}
+	}(succ.Address)
+}
+
+// adoptKeySpace rescales every id this node knows about. Scaling keeps the
+// ring order, so the ring stays whole while the keys move. The whole
+// rescale happens under the ring lock, so no lookup sees ids of both sizes.
+func (n *Node) adoptKeySpace(previous, target models.KeySpace) {
+	scale := func(id int64) int64 {
+		return helpers.ScalePosition(id, previous.M, target.M)
+	}
+
+	c := n.chord
+	c.mu.Lock()
+	n.id = scale(n.id)
+	c.predecessor.Id = scale(c.predecessor.Id)
+	for i := range c.successorList {
+		c.successorList[i].Id = scale(c.successorList[i].Id)
+	}
+
+	fingerTable := make([]models.NodeRepresentation, target.M, target.M)
+	for i := 0; i < len(fingerTable) && i < len(c.fingerTable); i++ {
+		fingerTable[i] = models.NodeRepresentation{Id: scale(c.fingerTable[i].Id), Address: c.fingerTable[i].Address}
+	}
+	c.fingerTable = fingerTable
+
+	n.M = target.M
+	n.hashAlgorithm = target.Hash
+	c.mu.Unlock()
+
+	n.persistId()
+	persistKeySpace(n.storage.Dir(), target)
+}
+
+// moveKeys places the keys this node owns at their position in the new key
+// space, the new owners copying them to their replicas, and drops the copies
+// the replicas hold at the previous position. The replicas leave the keys
+// they do not own to their owners.
+func (n *Node) moveKeys(current *migration) {
+	m := n.KeySpace().M
+	var keys []int64
+	for _, key := range n.storage.Keys() {
+		if n.mustKeyBeInNode(helpers.ScalePosition(key, current.previous.M, m)) {
+			keys = append(keys, key)
+		}
+	}
+	current.mu.Lock()
+	current.pending = int64(len(keys))
+	current.mu.Unlock()
+
+	for _, key := range keys {
+		moved, err := n.moveKey(key)
+		if moved {
+			n.dropPreviousCopies(key, helpers.ScalePosition(key, current.previous.M, m))
+		}
+		current.mu.Lock()
+		current.pending--
+		if err != nil {
+			log.Println("unable to migrate key " + strconv.FormatInt(key, 10) + ": " + err.Error())
+			current.unmigratable++
+		} else if moved {
+			current.moved++
+		}
+		current.mu.Unlock()
+	}
+
+	current.mu.Lock()
+	current.done = true
+	log.Printf("key migration done in this node, moved: %v, unmigratable: %v", current.moved, current.unmigratable)
+	current.mu.Unlock()
+}
+
+func (n *Node) moveKey(key int64) (bool, error) {
+	name, ok := n.storage.Name(key)
+	if !ok {
+		return n.moveBucket(key)
+	}
+
+	newKey := n.KeyPosition(name)
+	if newKey == key {
+		return false, nil
+	}
+
+	data, err := n.storage.Read(key)
+	if err != nil {
+		return false, err
+	}
+	if err := n.placeKey(newKey, name, data); err != nil {
+		return false, err
+	}
+	return true, n.storage.Delete(key)
+}
+
+// moveBucket moves the keys of an ordered namespace bucket. Entries saved
+// without their original key can't be placed again and are left as they are.
+func (n *Node) moveBucket(key int64) (bool, error) {
+	bucket, err := n.storage.ReadBucket(key)
+	if err != nil || len(bucket) == 0 {
+		return false, errors.New("the original key is unknown")
+	}
+
+	moved := false
+	for name, data := range bucket {
+		if !helpers.IsOrderedKey(name) {
+			return false, errors.New("the original key is unknown")
+		}
+		if n.KeyPosition(name) == key {
+			continue
+		}
+		if err := n.SaveOrdered(name, data); err != nil {
+			return moved, err
+		}
+		if _, err := n.storage.DeleteNamed(key, name); err != nil {
+			return moved, err
+		}
+		moved = true
+	}
+	return moved, nil
+}
+
+// dropPreviousCopies brings the copies of key the replicas of position hold
+// in line with what moving it left in this node: a bucket part of which
+// stayed, or nothing.
+func (n *Node) dropPreviousCopies(key int64, position int64) {
+	write := copyWrite{Key: key, Deleted: true, Version: time.Now().UnixNano()}
+	if data, err := n.storage.Read(key); err == nil {
+		write = copyWrite{Key: key, Data: data, Replace: true}
+	}
+	for _, target := range n.replicaTargets(position) {
+		if err := n.writeCopy(target.Address, write, false); err != nil {
+			n.replicationFailed(target.Address, write, err)
+		}
+	}
+}
+
+func (n *Node) placeKey(key int64, name string, data []byte) error {
+	if n.mustKeyBeInNode(key) {
+		if err := n.storage.Put(storage.Entry{Key: key, Data: data, Name: name}); err != nil {
+			return err
+		}
+		n.replicateLater(copyWrite{Key: key, Name: name, Data: data, Version: n.storage.Version(key), Replace: true})
+		return nil
+	}
+
+	response, err := n.Owner(key)
+	if err != nil {
+		return err
+	}
+	_, err = n.client.Save(response.OwnerNodeEndpoint, key, name, data, "")
+	return err
+}
+
+// QueryPreviousKeySpace looks a key up where the previous key space placed
+// it, for keys not moved yet.
+func (n *Node) QueryPreviousKeySpace(name string) *grpc_api.QueryResponse {
+	current := n.currentMigration()
+	if current == nil || name == "" {
+		return nil
+	}
+
+	previousKey := current.previous.Position(name)
+	owner, err := n.Owner(helpers.ScalePosition(previousKey, current.previous.M, n.KeySpace().M))
+	if err != nil {
+		log.Println(err.Error())
+		return nil
+	}
+
+	response := n.client.QueryLocal(owner.OwnerNodeEndpoint, previousKey, name)
+	if len(response.Data) == 0 {
+		return nil
+	}
+	return response
+}
+
+// QueryLocal reads a key from this node storage, whoever owns it.
+func (n *Node) QueryLocal(key int64, name string) grpc_api.QueryResponse {
+	var (
+		data []byte
+		err  error
+	)
+	if helpers.IsOrderedKey(name) {
+		data, err = n.storage.ReadNamed(key, name)
+	} else {
+		data, err = n.storage.Read(key)
+	}
+
+	// a key without data but with a version was deleted
+	var version int64
+	if err != nil {
+		data = []byte{}
+		version = n.storage.Tombstone(key)
+	} else {
+		version = n.storage.Version(key)
+	}
+	return grpc_api.QueryResponse{
+		Data:                    data,
+		ResponsibleNodeEndpoint: n.address,
+		ResponsibleNodeId:       n.Id(),
+		Version:                 version,
+	}
+}
+
+func (n *Node) MigrationStatus() *grpc_api.MigrationStatusResponse {
+	keySpace := n.KeySpace()
+	response := &grpc_api.MigrationStatusResponse{
+		M:             int32(keySpace.M),
+		HashAlgorithm: keySpace.Hash,
+		Complete:      true,
+		Nodes:         1,
+	}
+
+	current := n.currentMigration()
+	if current == nil {
+		return response
+	}
+
+	current.mu.Lock()
+	defer current.mu.Unlock()
+	response.Migrating = true
+	response.PendingKeys = current.pending
+	response.MovedKeys = current.moved
+	response.UnmigratableKeys = current.unmigratable
+	response.Complete = current.done
+	if !current.done {
+		response.PendingNodes = 1
+	}
+	return response
+}
+
+// ClusterMigrationStatus adds up the migration status of every node,
+// walking the ring through the successors.
+func (n *Node) ClusterMigrationStatus() (*grpc_api.MigrationStatusResponse, error) {
+	total := n.MigrationStatus()
+
+	current, err := n.Successor()
+	if err != nil {
+		return total, nil
+	}
+	address := current.Address
+	for address != n.address && address != "" {
+		status, err := n.client.MigrationStatus(address, false)
+		if err != nil {
+			return nil, err
+		}
+		total.Migrating = total.Migrating || status.Migrating
+		total.PendingKeys += status.PendingKeys
+		total.MovedKeys += status.MovedKeys
+		total.UnmigratableKeys += status.UnmigratableKeys
+		total.Nodes += status.Nodes
+		if !status.Complete || status.M != total.M || status.HashAlgorithm != total.HashAlgorithm {
+			total.PendingNodes++
+		}
+
+		succ, err := n.client.Successor(address)
+		if err != nil {
+			return nil, err
+		}
+		address = succ.Endpoint
+	}
+
+	total.Complete = total.PendingNodes == 0
+	return total, nil
+}
+
+// watchMigration runs on the node that started the migration and tells the
+// ring to stop reading from the previous key space once every node is done.
+func (n *Node) watchMigration(target models.KeySpace) {
+	for {
+		time.Sleep(migrationPollInterval)
+		if n.currentMigration() == nil {
+			return
+		}
+
+		status, err := n.ClusterMigrationStatus()
+		if err != nil {
+			log.Println("unable to get the cluster migration status: " + err.Error())
+			continue
+		}
+		if !status.Complete {
+			log.Printf("key space migration in progress, pending nodes: %v, pending keys: %v", status.PendingNodes, status.PendingKeys)
+			continue
+		}
+
+		log.Printf("key space migration complete in %v nodes, moved keys: %v", status.Nodes, status.MovedKeys)
+		n.Migrate(target, "", true)
+		return
+	}
+}
+
+func (m *migration) isDone() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.done
+}
@@ -2,153 +2,318 @@ package node
 
 import (
 	"errors"
-	"fmt"
 	"log"
 	"math"
 	"os"
+	"path/filepath"
 	"strconv"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/raonismaneoto/CustomDHT/commons/grpc_api"
 	"github.com/raonismaneoto/CustomDHT/commons/helpers"
 	client2 "github.com/raonismaneoto/CustomDHT/core/client"
+	"github.com/raonismaneoto/CustomDHT/core/membership"
 	"github.com/raonismaneoto/CustomDHT/core/models"
+	"github.com/raonismaneoto/CustomDHT/core/ownercache"
 	"github.com/raonismaneoto/CustomDHT/core/storage"
 )
 
+const maxIdSalts = 16
+
 type Node struct {
-	fingerTable       []models.NodeRepresentation
 	id                int64
 	address           string
-	storage           storage.Storage
-	predecessor       models.NodeRepresentation
-	nSucc             models.NodeRepresentation
+	storage           *storage.Storage
+	siblings          []*Node
 	M                 int
-	replicationBuffer chan storage.Entry
+	hashAlgorithm     string
+	migration         *migration
+	migrationMu       sync.Mutex
+	replicationBuffer chan replica
+	copyBuffer        chan copyWrite
 	client            *client2.Client
+	joined            bool
+	left              atomic.Bool
+	members           *membership.List
+	health            Health
+	healthMu          sync.Mutex
+	latencies         latencies
+	router            overlay
+	chord             *chordRouter
+	table             routingTable
+	history           history
+	started           time.Time
+	owners            *ownercache.Cache
+	replicas          []models.NodeRepresentation
+	replicasPlaced    bool
+	replicasMu        sync.Mutex
+	hints             hints
+}
+
+func New(id int64, address string, keySpace models.KeySpace, store *storage.Storage) *Node {
+	n := &Node{id: id, address: address, M: keySpace.M, hashAlgorithm: keySpace.Hash, storage: store, client: client2.New(), started: time.Now(), owners: ownercache.New(ownerCacheSize())}
+	n.copyBuffer = make(chan copyWrite, 50)
+	n.client.SetIdentity(n.Identity)
+	n.router = newRouter(n)
+	return n
 }
 
-func New(id int64, address string, m int) *Node {
-	return &Node{id: id, address: address, M: m, client: client2.New()}
+func fixFingersInterval() int {
+	return helpers.EnvInt("FIX_FINGERS_INTERVAL", 1, 5)
 }
 
-func (n *Node) Start(partnerId int64, partnerAddr string) {
-	partner := &models.NodeRepresentation{
-		Id:      partnerId,
-		Address: partnerAddr,
+func (n *Node) Id() int64 {
+	id, _ := n.position()
+	return id
+}
+
+// position is the id of the node and the size of its key space, both moved
+// under the ring lock by key space migrations.
+func (n *Node) position() (int64, int) {
+	if n.chord == nil {
+		return n.id, n.M
 	}
+	n.chord.mu.RLock()
+	defer n.chord.mu.RUnlock()
+	return n.id, n.M
+}
+
+func (n *Node) Address() string {
+	return n.address
+}
 
-	log.Println("Starting node: %v", n.id)
-	log.Println("partnerAddr: %v", partnerAddr)
-	log.Println("nodeAddr: %v", n.address)
-	n.replicationBuffer = make(chan storage.Entry, 50)
-	n.fingerTable = make([]models.NodeRepresentation, n.M, n.M)
-	n.storage = storage.New(models.GetMemTypeFromString(os.Getenv("STORAGE_TYPE")))
-	n.predecessor = models.NodeRepresentation{
-		Id:      0,
-		Address: "",
+// VirtualNodeCount is the number of ring positions this process takes,
+// VNODES_PER_WEIGHT scaled by the CAPACITY_WEIGHT of the host.
+func VirtualNodeCount() int {
+	perWeight := helpers.EnvInt("VNODES_PER_WEIGHT", 1, 1)
+	weight, err := strconv.ParseFloat(os.Getenv("CAPACITY_WEIGHT"), 64)
+	if err != nil || weight <= 0 {
+		weight = 1
 	}
-	n.nSucc = models.NodeRepresentation{
-		Id:      0,
-		Address: "",
+
+	count := int(math.Round(weight * float64(perWeight)))
+	if count < 1 {
+		return 1
 	}
+	return count
+}
 
-	if partner.Address != n.address {
-		n.Join(partner)
+// LinkSiblings lets the virtual nodes of one process answer for each other
+// without going through the network.
+func LinkSiblings(nodes []*Node) {
+	for _, n := range nodes {
+		n.siblings = nodes
 	}
+}
 
-	helpers.PeriodicInvocation(n.checkSucc, 300)
-	helpers.PeriodicInvocation(n.stabilize, 360)
+// Start joins the ring through the first of seeds taking the node in, or
+// starts a new ring when there are no seeds. It returns once the node is in
+// the ring, join failures are retried and reported by Health meanwhile.
+func (n *Node) Start(seeds []string) {
+	log.Printf("Starting node: %v", n.Id())
+	log.Printf("seeds: %v", seeds)
+	log.Printf("nodeAddr: %v", n.address)
+	n.replicationBuffer = make(chan replica, 50)
+	n.loadHints()
+
+	if len(seeds) > 0 {
+		n.joinWithRetry(seeds)
+	}
+	n.persistId()
+	n.joined = true
+	n.setHealth(HealthReady)
+	n.router.Start()
 
 	go n.syncReplicatedKeys()
-	n.printState()
-	helpers.PeriodicInvocation(n.printState, 600)
+	go n.sendCopies()
+	helpers.PeriodicInvocation(n.replayAllHints, hintReplayInterval())
+	helpers.PeriodicInvocation(n.expireTombstones, hintReplayInterval())
+	if stateDumpInterval() > 0 {
+		helpers.PeriodicInvocation(n.dumpState, stateDumpInterval())
+	}
+}
+
+type replica struct {
+	entry   storage.Entry
+	replace bool
 }
 
 func (n *Node) syncReplicatedKeys() {
 	for msg := range n.replicationBuffer {
-		err := n.storage.Save(msg)
-		if err != nil {
+		if err := n.storeReplica(msg); err != nil {
 			log.Println(err.Error())
 		}
 	}
 }
 
-func (n *Node) checkSucc() {
-	if !n.isFingerSet(0) {
-		return
+func (n *Node) storeReplica(msg replica) error {
+	// a hint replayed late must not take back a newer value or delete
+	if msg.replace {
+		_, err := n.storage.PutIfNewer(msg.entry)
+		return err
 	}
+	return n.storage.Save(msg.entry)
+}
 
-	_, err := n.client.Ping(n.fingerTable[0].Address)
+func successorListLength() int {
+	return helpers.EnvInt("SUCCESSOR_LIST_LENGTH", 1, 3)
+}
+
+func stabilizeInterval() int {
+	return helpers.EnvInt("STABILIZE_INTERVAL", 1, 5)
+}
+
+func checkPredecessorInterval() int {
+	return helpers.EnvInt("CHECK_PRED_INTERVAL", 1, 5)
+}
+
+func checkSuccInterval() int {
+	return helpers.EnvInt("CHECK_SUCC_INTERVAL", 1, 300)
+}
 
+// SuccessorList returns the successor followed by the next nodes of the
+// ring, as last learned from the successor.
+func (n *Node) SuccessorList() []models.NodeRepresentation {
+	return n.chord.successors()
+}
+
+func (n *Node) nextSuccessor() models.NodeRepresentation {
+	successors := n.SuccessorList()
+	if len(successors) < 2 {
+		return models.NodeRepresentation{}
+	}
+	return successors[1]
+}
+
+// Notify is candidate telling this node it might be its predecessor.
+func (n *Node) Notify(candidate models.NodeRepresentation) bool {
+	if n.chord == nil {
+		return false
+	}
+	return n.chord.notify(candidate)
+}
+
+// pullKeys copies the keys in (start, end] stored at address.
+func (n *Node) pullKeys(address string, start, end int64) {
+	keys, err := n.client.Keys(address, start, end)
 	if err != nil {
-		if n.nSucc.Address == "" {
-			return
+		log.Println("unable to list the keys of " + address + ": " + err.Error())
+		return
+	}
+	for i, key := range keys.Keys {
+		version := listedVersion(keys, i)
+		if _, err := n.storage.Read(key); err == nil && version != 0 && n.storage.Version(key) >= version {
+			continue
+		}
+		response := n.client.QueryLocal(address, key, "")
+		if len(response.Data) == 0 {
+			continue
 		}
+		if _, err := n.storage.PutIfNewer(storage.Entry{Key: key, Data: response.Data, Version: version}); err != nil {
+			log.Println(err.Error())
+		}
+	}
+	log.Println("pulled " + strconv.Itoa(len(keys.Keys)) + " keys from " + address)
+}
 
-		response, err := n.client.HandleNewPredecessor(n.nSucc.Address, models.NodeRepresentation{Id: n.id, Address: n.address})
+// listedVersion is the version of the i-th key of a Keys response, 0 when
+// the node listing it keeps none. Copies pulled without it would look newer
+// than the ones written since.
+func listedVersion(keys *grpc_api.KeysResponse, i int) int64 {
+	if i < len(keys.Versions) {
+		return keys.Versions[i]
+	}
+	return 0
+}
 
-		if err != nil || !response.Ok {
-			log.Println("handle new predecessor failed for nSucc.")
-			return
-		}
+// IdFilePath is where the node at address keeps its id, NODE_ID_FILE or
+// node-id in dir.
+func IdFilePath(dir string, address string) string {
+	path := os.Getenv("NODE_ID_FILE")
+	if path == "" {
+		path = filepath.Join(dir, "node-id")
+	}
+	if token := models.VirtualToken(address); token != "0" {
+		path += "-" + token
+	}
+	return path
+}
 
-		n.fingerTable[0] = models.NodeRepresentation{Id: n.nSucc.Id, Address: n.nSucc.Address}
+func (n *Node) persistId() {
+	if err := helpers.WriteIdFile(IdFilePath(n.storage.Dir(), n.address), n.Id()); err != nil {
+		log.Println("unable to persist node id: " + err.Error())
+	}
+}
 
-		nSucc, err := n.client.Successor(n.nSucc.Address)
+// resolveIdCollision re-salts the node id until no other node in the ring
+// holds it. The salts are tried in order so a given address always ends up
+// with the same id in the same ring.
+func (n *Node) resolveIdCollision(partner *models.NodeRepresentation) error {
+	for salt := 1; ; salt++ {
+		taken, err := n.isIdTaken(partner)
 		if err != nil {
-			log.Println("unable to reach nSucc in checkSucc. Err: " + err.Error())
+			return err
 		}
+		if !taken {
+			return nil
+		}
+		if salt > maxIdSalts {
+			break
+		}
+		keySpace := n.KeySpace()
+		log.Println("node id " + strconv.FormatInt(n.Id(), 10) + " is already taken, going to re-salt it")
+		n.router.setId(helpers.GetSaltedHash(n.address, salt, keySpace.M, keySpace.Hash))
+	}
 
-		n.nSucc = models.NodeRepresentation{Id: nSucc.Id, Address: n.address}
+	return errors.New("unable to find a free node id for " + n.address)
+}
 
-		n.syncKeys()
+func (n *Node) isIdTaken(partner *models.NodeRepresentation) (bool, error) {
+	id := n.Id()
+	owner, err := n.client.Owner(partner.Address, id)
+	if err != nil {
+		return false, err
 	}
+
+	// a node holding the id is the owner of it
+	return owner.OwnerNodeId == id && owner.OwnerNodeEndpoint != n.address, nil
 }
 
-func (n *Node) Join(partner *models.NodeRepresentation) {
-	log.Println("Starting finger table")
-	n.startFingerTable(partner)
-	// notify other nodes to update their predecessors and finger table
-	log.Println("Starting join notifications")
-	nodeRepresentation := models.NodeRepresentation{Id: n.id, Address: n.address}
-	newPredResponse, err := n.client.HandleNewPredecessor(n.fingerTable[0].Address, nodeRepresentation)
-	if err != nil || !newPredResponse.Ok {
-		log.Println("Successor did not accept new predecessor" + err.Error())
-		panic("Successor did not accept new predecessor")
-	}
-	newSuccResponse, err := n.client.HandleNewSuccessor(n.predecessor.Address, nodeRepresentation, models.NodeRepresentation{Id: 0, Address: ""})
-	if err != nil || !newSuccResponse.Ok {
-		log.Println("Predecessor did not accept new sucessor" + err.Error())
-		panic("Predecessor did not accept new sucessor")
-	}
-	// get the data
-	n.syncKeys()
+func (n *Node) RepSave(key int64, name string, data []byte, replace bool, version int64) {
+	n.replicationBuffer <- replica{entry: storage.Entry{Key: key, Data: data, Name: name, Version: version}, replace: replace}
 }
 
-func (n *Node) RepSave(key int64, data []byte) {
-	n.replicationBuffer <- storage.Entry{Key: key, Data: data}
+// StoreReplica is RepSave storing the copy before returning, for the owner
+// to count it as acknowledged.
+func (n *Node) StoreReplica(key int64, name string, data []byte, replace bool, version int64) error {
+	return n.storeReplica(replica{entry: storage.Entry{Key: key, Data: data, Name: name, Version: version}, replace: replace})
 }
 
-func (n *Node) Save(key int64, value []byte) error {
+// Save writes value at the owner of key, which copies it to the replicas.
+// The owner answers once consistency of the copies hold it.
+func (n *Node) Save(key int64, name string, value []byte, consistency string) error {
+	acks, err := Acks(consistency)
+	if err != nil {
+		return err
+	}
 	if n.mustKeyBeInNode(key) {
 		log.Println("saving the data in this node")
-		err := n.storage.Save(storage.Entry{Key: key, Data: value})
-		if err != nil {
-			log.Println(err.Error())
+		return n.saveOwned(key, name, value, acks)
+	}
+
+	if sibling := n.localOwner(key); sibling != nil {
+		return sibling.Save(key, name, value, consistency)
+	}
+
+	if owner, ok := n.cachedOwner(key); ok {
+		err := n.client.DirectSave(owner.Address, key, name, value, consistency)
+		// the owner applied a write missing its consistency level
+		if err == nil || models.IsConsistencyError(err) {
 			return err
 		}
-		inflectionPoint := (n.id-n.predecessor.Id)/2 + n.predecessor.Id
-		if key >= inflectionPoint {
-			if n.isFingerSet(0) {
-				n.client.RepSave(n.fingerTable[0].Address, key, value)
-			}
-		} else {
-			if n.predecessor.Address != "" {
-				n.client.RepSave(n.predecessor.Address, key, value)
-			}
-		}
-		return nil
+		n.dropOwner(owner.Address, err)
 	}
 
 	// else the request must be passed to the responsible node
@@ -157,38 +322,86 @@ func (n *Node) Save(key int64, value []byte) error {
 	if err != nil {
 		return err
 	}
-	_, err = n.client.Save(response.OwnerNodeEndpoint, key, value)
+	// a router not knowing of a closer node than this one owns the key
+	if response.OwnerNodeEndpoint == n.address {
+		return n.saveOwned(key, name, value, acks)
+	}
+	n.learnOwner(key, models.NodeRepresentation{Id: response.OwnerNodeId, Address: response.OwnerNodeEndpoint}, response.RangeStart, response.RangeKnown)
+	_, err = n.client.Save(response.OwnerNodeEndpoint, key, name, value, consistency)
 	return err
 }
 
-func (n *Node) Delete(key int64) error {
-	err := n.storage.Delete(key)
+// Delete deletes key at its owner, which deletes it at the replicas as well.
+func (n *Node) Delete(key int64, consistency string) error {
+	acks, err := Acks(consistency)
 	if err != nil {
-		log.Println(err.Error())
 		return err
 	}
+	if n.mustKeyBeInNode(key) {
+		return n.deleteOwned(key, acks)
+	}
+
+	if sibling := n.localOwner(key); sibling != nil {
+		return sibling.Delete(key, consistency)
+	}
 
-	inflectionPoint := (n.id-n.predecessor.Id)/2 + n.predecessor.Id
-	if key >= inflectionPoint {
-		if n.isFingerSet(0) {
-			n.client.Delete(n.fingerTable[0].Address, key)
+	response, err := n.Owner(key)
+	if err != nil {
+		return err
+	}
+	if response.OwnerNodeEndpoint == n.address {
+		return n.deleteOwned(key, acks)
+	}
+	_, err = n.client.Delete(response.OwnerNodeEndpoint, key, consistency)
+	return err
+}
+
+// DeleteReplica deletes the copy of key this node holds for its owner, as
+// of version.
+func (n *Node) DeleteReplica(key int64, version int64) error {
+	_, err := n.storage.Remove(key, version)
+	return err
+}
+
+// localOwner returns the virtual node of this process responsible for key,
+// if there is one.
+func (n *Node) localOwner(key int64) *Node {
+	for _, sibling := range n.siblings {
+		if sibling != n && sibling.isReady() && sibling.mustKeyBeInNode(key) {
+			return sibling
 		}
-	} else {
-		if n.predecessor.Address != "" {
-			n.client.Delete(n.predecessor.Address, key)
+	}
+	return nil
+}
+
+// isLocal tells whether address is this node or a virtual node of its
+// process, all of them sharing one storage.
+func (n *Node) isLocal(address string) bool {
+	if address == n.address {
+		return true
+	}
+	for _, sibling := range n.siblings {
+		if sibling.address == address {
+			return true
 		}
 	}
+	return false
+}
 
-	return nil
+func (n *Node) isReady() bool {
+	return n.joined
 }
 
-func (n *Node) QueryAsync(key int64, cbuffer chan grpc_api.QueryResponse) {
+// QueryAsync streams the value of key to cbuffer when this node owns it,
+// or else answers with the owner for the caller to ask. Failures go to
+// ebuffer.
+func (n *Node) QueryAsync(key int64, cbuffer chan *grpc_api.QueryResponse, ebuffer chan error) {
 	if n.mustKeyBeInNode(key) {
 		log.Println("going to return the query from this node")
-		ebuffer := make(chan error)
+		readErrors := make(chan error)
 		bcbuffer := make(chan []byte)
 
-		go n.storage.ReadAsync(key, bcbuffer, ebuffer)
+		go n.storage.ReadAsync(key, bcbuffer, readErrors)
 
 		for {
 			select {
@@ -197,21 +410,21 @@ func (n *Node) QueryAsync(key int64, cbuffer chan grpc_api.QueryResponse) {
 					close(cbuffer)
 					return
 				} else {
-					resp := grpc_api.QueryResponse{
+					resp := &grpc_api.QueryResponse{
 						Data:                    content,
 						ResponsibleNodeEndpoint: n.address,
-						ResponsibleNodeId:       n.id,
+						ResponsibleNodeId:       n.Id(),
 					}
 					cbuffer <- resp
 				}
-			case err, ok := <-ebuffer:
+			case err, ok := <-readErrors:
 				if !ok {
 					close(cbuffer)
 					return
 				}
 				if err != nil && err.Error() == "Key not found" {
 					log.Println("Key" + strconv.FormatInt(key, 10) + " not found.")
-					resp := grpc_api.QueryResponse{
+					resp := &grpc_api.QueryResponse{
 						Data:                    []byte{},
 						ResponsibleNodeEndpoint: "",
 					}
@@ -219,311 +432,187 @@ func (n *Node) QueryAsync(key int64, cbuffer chan grpc_api.QueryResponse) {
 					close(cbuffer)
 					return
 				}
+				if err != nil {
+					ebuffer <- err
+					return
+				}
 			}
 		}
 
 	}
 
-	aimingNode := n.findAimingNode(key)
-	if aimingNode.Address != "" {
-		log.Println("key not found in node, going to forward the query to:")
-		log.Println("nodeAddress: " + aimingNode.Address)
-		owner, err := n.client.Owner(aimingNode.Address, key)
-		if err != nil {
-			resp := grpc_api.QueryResponse{
-				Data:                    []byte{},
-				ResponsibleNodeEndpoint: "",
-			}
-			cbuffer <- resp
-		}
-		resp := grpc_api.QueryResponse{
-			Data:                    []byte{},
-			ResponsibleNodeEndpoint: owner.OwnerNodeEndpoint,
-			ResponsibleNodeId:       owner.OwnerNodeId,
-		}
-		cbuffer <- resp
+	owner, err := n.Owner(key)
+	if err != nil {
+		ebuffer <- err
+		return
 	}
-
-	log.Println("unable to query for key" + strconv.FormatInt(key, 10))
-	log.Println("going to panic")
-	panic("unable to query for key" + strconv.FormatInt(key, 10))
-}
-
-func (n *Node) Query(key int64) grpc_api.QueryResponse {
-	if n.mustKeyBeInNode(key) {
-		log.Println("going to return the query from this node")
-		data, err := n.storage.Read(key)
-
-		if err != nil && err.Error() == "Key not found" {
-			log.Println("Key" + strconv.FormatInt(key, 10) + " not found.")
-			return grpc_api.QueryResponse{
-				Data:                    []byte{},
-				ResponsibleNodeEndpoint: n.address,
-				ResponsibleNodeId:       n.id,
-			}
-		}
-
-		return grpc_api.QueryResponse{
-			Data:                    data,
-			ResponsibleNodeEndpoint: n.address,
-			ResponsibleNodeId:       n.id,
-		}
+	// a router not knowing of a closer node than this one would send the
+	// caller back here
+	if owner.OwnerNodeEndpoint == n.address {
+		ebuffer <- errors.New("unable to query for key " + strconv.FormatInt(key, 10))
+		return
 	}
-
-	aimingNode := n.findAimingNode(key)
-	if aimingNode.Address != "" {
-		log.Println("key not found in node, going to forward the query to:")
-		log.Println("nodeAddress: " + aimingNode.Address)
-		return *n.client.Query(aimingNode.Address, key)
+	log.Println("key not found in node, going to send the query to: " + owner.OwnerNodeEndpoint)
+	cbuffer <- &grpc_api.QueryResponse{
+		Data:                    []byte{},
+		ResponsibleNodeEndpoint: owner.OwnerNodeEndpoint,
+		ResponsibleNodeId:       owner.OwnerNodeId,
 	}
-
-	log.Println("unable to query for key" + strconv.FormatInt(key, 10))
-	log.Println("going to panic")
-	panic("unable to query for key" + strconv.FormatInt(key, 10))
+	close(cbuffer)
 }
 
-func distance(i int64, j int64, m int) int64 {
-	if j == i {
-		return 0
-	}
-	if j > i {
-		return j - i
-	}
-	return int64(math.Pow(2, float64(m))) - i + j
+func (n *Node) Query(key int64) (*grpc_api.QueryResponse, error) {
+	return n.RoutedQuery(key, Route{})
 }
 
-func (n *Node) HandleNewSuccessor(newSucc models.NodeRepresentation, nNSucc models.NodeRepresentation) error {
-	if n.fingerTable[0].Address != "" && distance(n.id, newSucc.Id, n.M) > distance(n.id, n.fingerTable[0].Id, n.M) {
-		log.Println("ping current succ")
-		_, err := n.client.Ping(n.fingerTable[0].Address)
-		if err == nil {
-			return errors.New("invalid successor")
-		}
-	}
-
-	n.fingerTable[0] = newSucc
-	if nNSucc.Address != "" && nNSucc.Id != n.id {
-		n.nSucc = nNSucc
+// RoutedQuery is Query for a lookup that may already have gone through
+// other nodes, as told by route. With route.Trace every hop is recorded in
+// the response path, the first hop being this node.
+func (n *Node) RoutedQuery(key int64, route Route) (*grpc_api.QueryResponse, error) {
+	start := time.Now()
+	response, err := n.query(key, route)
+	if route.Hops == 0 && err == nil {
+		n.observeLookup(start, response.Hops)
 	}
-	//it should be a msg in a channel
-	go n.syncSuccKeys()
-
-	return nil
+	return response, err
 }
 
-func (n *Node) HandleNewPredecessor(nPred models.NodeRepresentation) error {
-	if n.predecessor.Address != "" && distance(n.id, nPred.Id, n.M) < distance(n.id, n.predecessor.Id, n.M) {
-		_, err := n.client.Ping(n.predecessor.Address)
-		if err == nil {
-			return errors.New("invalid predecessor")
+func (n *Node) query(key int64, route Route) (*grpc_api.QueryResponse, error) {
+	start := time.Now()
+	response, err := n.resolveQuery(key, route)
+	if route.Trace && response != nil {
+		hop := n.hop(start)
+		if isRoutingError(err) {
+			hop.Error = err.Error()
 		}
+		response.Path = append([]*grpc_api.Hop{hop}, response.Path...)
 	}
-
-	n.predecessor = nPred
-	// check if this goroutine will die after this function return statement
-	go n.syncPredKeys()
-
-	return nil
+	return response, err
 }
 
-func (n *Node) Successor() (*models.NodeRepresentation, error) {
-	if n.fingerTable == nil || len(n.fingerTable) == 0 || n.fingerTable[0].Address == "" {
-		return nil, errors.New("There is no successor")
-	}
-
-	return &n.fingerTable[0], nil
-}
-
-func (n *Node) Predecessor() (models.NodeRepresentation, error) {
-	if n.predecessor.Address == "" {
-		return n.predecessor, errors.New("There is no predecessor")
-	}
-
-	return n.predecessor, nil
-}
-
-func (n *Node) Owner(key int64) (*grpc_api.OwnerResponse, error) {
+func (n *Node) resolveQuery(key int64, route Route) (*grpc_api.QueryResponse, error) {
 	if n.mustKeyBeInNode(key) {
-		return &grpc_api.OwnerResponse{
-			OwnerNodeId:       n.id,
-			OwnerNodeEndpoint: n.address,
-		}, nil
-	}
-
-	aimingNode := n.findAimingNode(key)
-	log.Println("aimingNOdeAddr:")
-	log.Println(aimingNode.Address)
-	log.Println(aimingNode.Id)
-	log.Println(n.fingerTable[0].Address)
-	if aimingNode.Address != "" {
-		log.Println("going to forward the query to:")
-		log.Println("nodeAddress: " + aimingNode.Address)
-		return n.client.Owner(aimingNode.Address, key)
-	}
-
-	return nil, errors.New("unable to get the owner of the key: " + fmt.Sprint(key))
-
-}
-
-func (n *Node) stabilize() {
-	for i := 1; i < n.M; i++ {
-		currNodeInfo, err := n.Owner((n.id + int64(math.Pow(2, float64(i-1)))) % int64(math.Pow(2, float64(n.M))))
+		log.Println("going to return the query from this node")
+		acks, err := Acks(route.Consistency)
 		if err != nil {
-			log.Println(err.Error())
-			continue
+			return &grpc_api.QueryResponse{}, err
 		}
-		if currNodeInfo.OwnerNodeEndpoint == "" {
-			continue
-		}
-		if currNodeInfo.OwnerNodeId != n.id && currNodeInfo.OwnerNodeEndpoint != n.address {
-			n.fingerTable[i] = models.NodeRepresentation{Id: currNodeInfo.OwnerNodeId, Address: currNodeInfo.OwnerNodeEndpoint}
+		response, err := n.readOwned(key, acks)
+		if err != nil {
+			return &grpc_api.QueryResponse{}, err
 		}
+		response.Hops = route.Hops
+		response.RangeStart, response.RangeKnown = n.router.OwnedRange()
+		return response, nil
 	}
-}
 
-func (n *Node) startFingerTable(partner *models.NodeRepresentation) {
-	log.Println("querying succ info in startFingerTable")
-	succInfo, err := n.client.Owner(partner.Address, n.id)
-	if err != nil {
-		log.Println("going to panic: " + err.Error())
-		panic(err.Error())
+	if sibling := n.localOwner(key); sibling != nil {
+		return sibling.query(key, route)
 	}
-	succ := models.NodeRepresentation{Id: succInfo.OwnerNodeId, Address: succInfo.OwnerNodeEndpoint}
-	n.fingerTable[0] = succ
 
-	nSuccInfo, err := n.client.Successor(n.fingerTable[0].Address)
-	if err != nil {
-		//if the partner does not have successor it does not have predecessor either
-		log.Println("Unable to find nsucc on finger table startup. Err: %v", err)
-		n.predecessor = succ
-	} else {
-		n.nSucc = models.NodeRepresentation{Id: nSuccInfo.Id, Address: nSuccInfo.Endpoint}
-		predecessor, err := n.client.Predecessor(n.fingerTable[0].Address)
+	if owner, ok := n.cachedOwner(key); ok && !n.isSuspected(owner.Address) {
+		next, err := n.forward(route)
 		if err != nil {
-			log.Println(err.Error())
+			return &grpc_api.QueryResponse{}, err
 		}
-		n.predecessor = models.NodeRepresentation{Id: predecessor.Id, Address: predecessor.Endpoint}
-	}
-
-	for i := 1; i < n.M; i++ {
-		currNodeInfo, err := n.Owner((n.id + int64(math.Pow(2, float64(i-1)))) % int64(math.Pow(2, float64(n.M))))
-		if err != nil {
-			log.Println(err.Error())
-			continue
-		}
-		if currNodeInfo.OwnerNodeId != n.id && currNodeInfo.OwnerNodeEndpoint != n.address {
-			n.fingerTable[i] = models.NodeRepresentation{Id: currNodeInfo.OwnerNodeId, Address: currNodeInfo.OwnerNodeEndpoint}
+		request := next.queryRequest(key)
+		request.Direct = true
+		response, err := n.client.ForwardQuery(owner.Address, request)
+		if err == nil {
+			return response, nil
 		}
-	}
-}
-
-func (n *Node) mustKeyBeInNode(key int64) bool {
-	if n.predecessor.Address == "" {
-		return true
+		n.dropOwner(owner.Address, err)
 	}
 
-	if n.predecessor.Address != "" && n.predecessor.Id > n.id {
-		return key < n.id || key >= n.predecessor.Id
-	}
-
-	return (key >= n.predecessor.Id && key < n.id)
+	return n.router.routeQuery(key, route)
 }
 
-func (n *Node) keysRange() (int64, int64) {
-	predOfPred, _ := n.client.Predecessor(n.predecessor.Address)
-	start := (n.predecessor.Id-predOfPred.Id)/2 + predOfPred.Id
-	end := (n.nSucc.Id-n.fingerTable[0].Id)/2 + n.fingerTable[0].Id
-	return start, end
+func (n *Node) HandleNewSuccessor(newSucc models.NodeRepresentation, nNSucc models.NodeRepresentation) error {
+	if n.chord == nil {
+		return errNotOnRing
+	}
+	return n.chord.handleNewSuccessor(newSucc, nNSucc)
 }
 
-func (n *Node) syncKey(address string, key int64) {
-	response := n.client.Query(address, key)
-	if response.Data != nil && len(response.Data) > 0 {
-		err := n.storage.Save(storage.Entry{Key: key, Data: response.Data})
-		if err != nil {
-			log.Println(err.Error())
-		}
+func (n *Node) HandleNewPredecessor(nPred models.NodeRepresentation) error {
+	if n.chord == nil {
+		return errNotOnRing
 	}
+	return n.chord.handleNewPredecessor(nPred)
 }
 
-func (n *Node) syncKeys() {
-	log.Println("starting sync keys")
-	start, end := n.keysRange()
-	for i := start; i <= end; i++ {
-		if i < n.id {
-			n.syncKey(n.predecessor.Address, i)
-		} else {
-			n.syncKey(n.fingerTable[0].Address, i)
-		}
+func (n *Node) Successor() (*models.NodeRepresentation, error) {
+	succ := n.chord.successor()
+	if succ.Address == "" {
+		return nil, errors.New("There is no successor")
 	}
+
+	return &succ, nil
 }
 
-func (n *Node) syncSuccKeys() {
-	if n.fingerTable[0].Address == "" || n.nSucc.Address == "" {
-		return
+func (n *Node) Predecessor() (models.NodeRepresentation, error) {
+	pred, _ := n.chord.neighbours()
+	if pred.Address == "" {
+		return pred, errors.New("There is no predecessor")
 	}
-	start := n.id
-	end := (n.nSucc.Id-n.fingerTable[0].Id)/2 + n.fingerTable[0].Id
 
-	for i := start; i <= end; i++ {
-		n.syncKey(n.fingerTable[0].Address, i)
-	}
+	return pred, nil
 }
 
-func (n *Node) syncPredKeys() {
-	predOfPred, _ := n.client.Predecessor(n.predecessor.Address)
-	if predOfPred.Endpoint == "" {
-		return
-	}
-	start := (n.predecessor.Id-predOfPred.Id)/2 + predOfPred.Id
-	end := n.predecessor.Id - 1
+func (n *Node) Owner(key int64) (*grpc_api.OwnerResponse, error) {
+	return n.RoutedOwner(key, Route{})
+}
 
-	for i := start; i <= end; i++ {
-		n.syncKey(n.predecessor.Address, i)
+// RoutedOwner is Owner for a lookup that may already have gone through
+// other nodes, as told by route. With route.Trace every hop is recorded in
+// the response path, the first hop being this node.
+func (n *Node) RoutedOwner(key int64, route Route) (*grpc_api.OwnerResponse, error) {
+	start := time.Now()
+	response, err := n.owner(key, route)
+	if route.Hops == 0 && err == nil {
+		n.observeLookup(start, response.Hops)
 	}
+	return response, err
 }
 
-func (n *Node) isFingerSet(index int) bool {
-	return n.fingerTable != nil && len(n.fingerTable) >= index && n.fingerTable[index].Address != ""
+func (n *Node) owner(key int64, route Route) (*grpc_api.OwnerResponse, error) {
+	start := time.Now()
+	response, err := n.resolveOwner(key, route)
+	if route.Trace && response != nil {
+		response.Path = append([]*grpc_api.Hop{n.hop(start)}, response.Path...)
+	}
+	return response, err
 }
 
-func (n *Node) findAimingNode(key int64) *models.NodeRepresentation {
-	aimingNode := n.fingerTable[0]
-	possibleNodes := append(n.fingerTable, n.predecessor)
+func (n *Node) resolveOwner(key int64, route Route) (*grpc_api.OwnerResponse, error) {
+	if n.mustKeyBeInNode(key) {
+		rangeStart, rangeKnown := n.router.OwnedRange()
+		return &grpc_api.OwnerResponse{
+			OwnerNodeId:       n.Id(),
+			OwnerNodeEndpoint: n.address,
+			Hops:              route.Hops,
+			RangeStart:        rangeStart,
+			RangeKnown:        rangeKnown,
+		}, nil
+	}
 
-	smallestDistance := int64(math.Pow(2, float64(n.M))) + 1000
-	for _, node := range possibleNodes {
-		if node.Id == 0 || node.Address == "" {
-			continue
-		}
-		currentDistance := distance(node.Id, key, n.M)
-		if currentDistance < smallestDistance {
-			smallestDistance = currentDistance
-			aimingNode = node
-		}
+	if sibling := n.localOwner(key); sibling != nil {
+		return sibling.owner(key, route)
 	}
 
-	return &aimingNode
+	return n.router.routeOwner(key, route)
 }
 
-func (n *Node) printState() {
-	now := time.Now()
-	time := now.Unix()
-	f, err := os.OpenFile("./state-"+fmt.Sprint(time), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
-	if err != nil {
-		log.Println("error to save state file")
-	}
-
-	defer f.Close()
+func (n *Node) hop(start time.Time) *grpc_api.Hop {
+	return &grpc_api.Hop{Id: n.Id(), Endpoint: n.address, ElapsedMicros: time.Since(start).Microseconds()}
+}
 
-	stateStr := "Successor: " + n.fingerTable[0].Address + ", " + fmt.Sprint(n.fingerTable[0].Id)
-	stateStr += "\nPredecessor: " + n.predecessor.Address + ", " + fmt.Sprint(n.predecessor.Id)
-	stateStr += "\nNSuccessor: " + n.nSucc.Address + ", " + fmt.Sprint(n.nSucc.Id)
-	for i, finger := range n.fingerTable {
-		stateStr += "\n Finger " + fmt.Sprint(i) + ": " + finger.Address + ", " + fmt.Sprint(finger.Id)
-	}
+// mustKeyBeInNode tells whether this node is responsible for key, as the
+// overlay decides it.
+func (n *Node) mustKeyBeInNode(key int64) bool {
+	return n.router.IsResponsible(key)
+}
 
-	if _, err := f.WriteString(stateStr); err != nil {
-		log.Println("unable to write state")
-	}
+func (n *Node) self() models.NodeRepresentation {
+	return models.NodeRepresentation{Id: n.Id(), Address: n.address}
 }
@@ -0,0 +1,112 @@
+package node
+
+import (
+	"log"
+	"sort"
+	"sync"
+
+	"github.com/raonismaneoto/CustomDHT/commons/helpers"
+	"github.com/raonismaneoto/CustomDHT/core/models"
+)
+
+// routingTable is the whole ring as a one hop node knows it, sorted by id.
+type routingTable struct {
+	mu    sync.Mutex
+	nodes []models.NodeRepresentation
+}
+
+func oneHopInterval() int {
+	return helpers.EnvInt("ONE_HOP_REFRESH_INTERVAL", 1, 5)
+}
+
+func isOneHop() bool {
+	return lookupMode() == OneHopLookup
+}
+
+// walkRing rebuilds the routing table going around the ring through the
+// successor lists, a call covering as many nodes as the lists hold.
+func (n *Node) walkRing() {
+	current := n.chord.successor()
+	if n.left.Load() || current.Address == "" {
+		return
+	}
+	seen := map[string]bool{n.address: true}
+	nodes := []models.NodeRepresentation{n.self()}
+	for steps := 0; steps < 1<<10 && !seen[current.Address]; steps++ {
+		seen[current.Address] = true
+		nodes = append(nodes, current)
+		response, err := n.client.SuccessorList(current.Address)
+		if err != nil {
+			log.Println("ring walk stopped at " + current.Address + ": " + err.Error())
+			return
+		}
+
+		// the last successor listed is where the walk goes on from
+		next := n.self()
+		for i, entry := range response.Successors {
+			node := models.NodeRepresentation{Id: entry.Id, Address: entry.Endpoint}
+			if seen[node.Address] {
+				break
+			}
+			if i == len(response.Successors)-1 {
+				next = node
+				break
+			}
+			seen[node.Address] = true
+			nodes = append(nodes, node)
+		}
+		current = next
+	}
+
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].Id < nodes[j].Id })
+	n.table.mu.Lock()
+	n.table.nodes = nodes
+	n.table.mu.Unlock()
+}
+
+func (n *Node) learn(node models.NodeRepresentation) {
+	if !isOneHop() || node.Address == "" {
+		return
+	}
+	n.table.mu.Lock()
+	defer n.table.mu.Unlock()
+	i := sort.Search(len(n.table.nodes), func(i int) bool { return n.table.nodes[i].Id >= node.Id })
+	if i < len(n.table.nodes) && n.table.nodes[i].Id == node.Id {
+		n.table.nodes[i] = node
+		return
+	}
+	n.table.nodes = append(n.table.nodes, models.NodeRepresentation{})
+	copy(n.table.nodes[i+1:], n.table.nodes[i:])
+	n.table.nodes[i] = node
+}
+
+func (n *Node) forget(address string) {
+	n.table.mu.Lock()
+	defer n.table.mu.Unlock()
+	for i, node := range n.table.nodes {
+		if node.Address == address {
+			n.table.nodes = append(n.table.nodes[:i:i], n.table.nodes[i+1:]...)
+			return
+		}
+	}
+}
+
+// oneHopOwner is the successor of key in the routing table. It holds as long
+// as the table has another node than this one owning key, the ring routing
+// taking over otherwise.
+func (n *Node) oneHopOwner(key int64) (models.NodeRepresentation, bool) {
+	if !isOneHop() {
+		return models.NodeRepresentation{}, false
+	}
+	n.table.mu.Lock()
+	defer n.table.mu.Unlock()
+	if len(n.table.nodes) == 0 {
+		return models.NodeRepresentation{}, false
+	}
+	i := sort.Search(len(n.table.nodes), func(i int) bool { return n.table.nodes[i].Id >= key })
+	if i == len(n.table.nodes) {
+		i = 0
+	}
+	owner := n.table.nodes[i]
+	return owner, owner.Address != n.address
+}
@@ -0,0 +1,277 @@
+package node
+
+import (
+	"errors"
+	"log"
+	"sort"
+	"strconv"
+
+	"github.com/raonismaneoto/CustomDHT/commons/grpc_api"
+	"github.com/raonismaneoto/CustomDHT/commons/helpers"
+	"github.com/raonismaneoto/CustomDHT/core/models"
+	"github.com/raonismaneoto/CustomDHT/core/ring"
+	"github.com/raonismaneoto/CustomDHT/core/storage"
+)
+
+const loadImbalanceFactor = 2
+
+func loadBalanceInterval() int {
+	return helpers.EnvInt("LOAD_BALANCE_INTERVAL", 1, 600)
+}
+
+func (n *Node) SaveOrdered(name string, value []byte) error {
+	key := n.KeyPosition(name)
+	if n.mustKeyBeInNode(key) {
+		log.Println("saving the ordered key in this node")
+		bucket, err := n.storage.SaveNamed(key, name, value)
+		if err != nil {
+			log.Println(err.Error())
+			return err
+		}
+		n.replicateLater(copyWrite{Key: key, Data: bucket, Replace: true})
+		return nil
+	}
+
+	if sibling := n.localOwner(key); sibling != nil {
+		return sibling.SaveOrdered(name, value)
+	}
+
+	log.Println("going to try to forward the ordered save")
+	response, err := n.Owner(key)
+	if err != nil {
+		return err
+	}
+	_, err = n.client.SaveWithStrKey(response.OwnerNodeEndpoint, name, value)
+	return err
+}
+
+func (n *Node) QueryOrdered(name string) grpc_api.QueryResponse {
+	key := n.KeyPosition(name)
+	if n.mustKeyBeInNode(key) {
+		data, err := n.storage.ReadNamed(key, name)
+		if err != nil {
+			log.Println("Key " + name + " not found.")
+			data = []byte{}
+		}
+		return grpc_api.QueryResponse{
+			Data:                    data,
+			ResponsibleNodeEndpoint: n.address,
+			ResponsibleNodeId:       n.Id(),
+		}
+	}
+
+	if sibling := n.localOwner(key); sibling != nil {
+		return sibling.QueryOrdered(name)
+	}
+
+	response, err := n.Owner(key)
+	if err != nil {
+		log.Println(err.Error())
+		return grpc_api.QueryResponse{Data: []byte{}}
+	}
+	return *n.client.QueryWithStrKey(response.OwnerNodeEndpoint, name)
+}
+
+func (n *Node) DeleteOrdered(name string) error {
+	key := n.KeyPosition(name)
+	if n.mustKeyBeInNode(key) {
+		bucket, err := n.storage.DeleteNamed(key, name)
+		if err != nil {
+			log.Println(err.Error())
+			return err
+		}
+		n.replicateLater(copyWrite{Key: key, Data: bucket, Replace: true, Deleted: len(bucket) == 0})
+		return nil
+	}
+
+	if sibling := n.localOwner(key); sibling != nil {
+		return sibling.DeleteOrdered(name)
+	}
+
+	response, err := n.Owner(key)
+	if err != nil {
+		return err
+	}
+	_, err = n.client.DeleteWithStrKey(response.OwnerNodeEndpoint, name)
+	return err
+}
+
+// RangeQuery returns the keys of namespace between start and end, both
+// inclusive, walking the successors from the owner of start.
+func (n *Node) RangeQuery(namespace, start, end string, limit int) ([]*grpc_api.KeyValue, error) {
+	if end < start {
+		return nil, errors.New("invalid range, end comes before start")
+	}
+	m := n.KeySpace().M
+	startPosition := helpers.GetOrderedHash(start, m)
+	endPosition := helpers.GetOrderedHash(end, m)
+
+	owner, err := n.Owner(startPosition)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []*grpc_api.KeyValue
+	current := owner.OwnerNodeEndpoint
+	wrapped := false
+	for {
+		request := &grpc_api.RangeQueryRequest{
+			Namespace: namespace,
+			Start:     start,
+			End:       end,
+			Limit:     int32(limit - len(items)),
+			Local:     true,
+		}
+		if limit <= 0 {
+			request.Limit = 0
+		}
+		response, err := n.client.RangeQuery(current, request)
+		if err != nil {
+			return items, err
+		}
+		items = append(items, response.Items...)
+
+		if limit > 0 && len(items) >= limit {
+			break
+		}
+		// every node holds the positions up to its id, so the walk is over
+		// once a node id reaches the end of the range
+		if wrapped || response.NodeId >= endPosition || response.NodeId < startPosition ||
+			response.SuccessorEndpoint == "" || response.SuccessorEndpoint == owner.OwnerNodeEndpoint {
+			break
+		}
+		wrapped = response.SuccessorId <= response.NodeId
+		current = response.SuccessorEndpoint
+	}
+
+	sort.Slice(items, func(i, j int) bool { return items[i].Key < items[j].Key })
+	if limit > 0 && len(items) > limit {
+		items = items[:limit]
+	}
+	return items, nil
+}
+
+func (n *Node) LocalRange(namespace, start, end string, limit int) []*grpc_api.KeyValue {
+	m := n.KeySpace().M
+	startPosition := helpers.GetOrderedHash(start, m)
+	endPosition := helpers.GetOrderedHash(end, m)
+
+	var items []*grpc_api.KeyValue
+	for _, key := range n.storage.Keys() {
+		if key < startPosition || key > endPosition || !n.mustKeyBeInNode(key) {
+			continue
+		}
+		bucket, err := n.storage.ReadBucket(key)
+		if err != nil {
+			log.Println("skipping key " + strconv.FormatInt(key, 10) + " in range query: " + err.Error())
+			continue
+		}
+		for name, value := range bucket {
+			keyNamespace, rest, ok := helpers.SplitOrderedKey(name)
+			if !ok || keyNamespace != namespace || rest < start || rest > end {
+				continue
+			}
+			items = append(items, &grpc_api.KeyValue{Key: name, Value: value})
+		}
+	}
+
+	sort.Slice(items, func(i, j int) bool { return items[i].Key < items[j].Key })
+	if limit > 0 && len(items) > limit {
+		items = items[:limit]
+	}
+	return items
+}
+
+// Load returns how many keys the node owns and the owned key splitting them
+// in half.
+func (n *Node) Load() (int64, int64) {
+	var owned []int64
+	for _, key := range n.storage.Keys() {
+		if n.mustKeyBeInNode(key) {
+			owned = append(owned, key)
+		}
+	}
+	id, m := n.position()
+	if len(owned) == 0 {
+		return 0, id
+	}
+
+	pred, _ := n.chord.neighbours()
+	sort.Slice(owned, func(i, j int) bool {
+		return ring.Distance(pred.Id, owned[i], m) < ring.Distance(pred.Id, owned[j], m)
+	})
+	return int64(len(owned)), owned[len(owned)/2]
+}
+
+// Keys lists the stored keys in (start, end].
+func (n *Node) Keys(start, end int64) []int64 {
+	var keys []int64
+	for _, key := range n.storage.Keys() {
+		if ring.InHalfOpenInterval(key, start, end) {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// Version is the version of the value stored under key.
+func (n *Node) Version(key int64) int64 {
+	return n.storage.Version(key)
+}
+
+// balanceLoad moves the node id forward, taking over part of the successor
+// range, when the successor holds far more keys than this node.
+func (n *Node) balanceLoad() {
+	pred, succ := n.chord.neighbours()
+	if n.left.Load() || succ.Address == "" || succ.Address == n.address {
+		return
+	}
+
+	succLoad, err := n.client.Load(succ.Address)
+	if err != nil {
+		log.Println("unable to get the successor load: " + err.Error())
+		return
+	}
+	ownLoad, _ := n.Load()
+	if succLoad.KeyCount <= loadImbalanceFactor*(ownLoad+1) {
+		return
+	}
+
+	id, newId := n.Id(), succLoad.SplitKey
+	if !ring.InOpenInterval(newId, id, succ.Id) {
+		return
+	}
+
+	log.Println("moving node id from " + strconv.FormatInt(id, 10) + " to " + strconv.FormatInt(newId, 10))
+	keys, err := n.client.Keys(succ.Address, id, newId)
+	if err != nil {
+		log.Println("unable to list the successor keys: " + err.Error())
+		return
+	}
+	for i, key := range keys.Keys {
+		response := n.client.Query(succ.Address, key)
+		if len(response.Data) == 0 {
+			continue
+		}
+		if _, err := n.storage.PutIfNewer(storage.Entry{Key: key, Data: response.Data, Version: listedVersion(keys, i)}); err != nil {
+			log.Println(err.Error())
+			return
+		}
+	}
+
+	if !n.chord.moveId(id, newId) {
+		log.Println("node id changed while moving it, leaving it as it is")
+		return
+	}
+	n.persistId()
+
+	nodeRepresentation := models.NodeRepresentation{Id: newId, Address: n.address}
+	if _, err := n.client.HandleNewPredecessor(succ.Address, nodeRepresentation); err != nil {
+		log.Println("successor did not accept the moved id: " + err.Error())
+	}
+	if pred.Address != "" {
+		if _, err := n.client.HandleNewSuccessor(pred.Address, nodeRepresentation, succ); err != nil {
+			log.Println("predecessor did not accept the moved id: " + err.Error())
+		}
+	}
+}
@@ -0,0 +1,240 @@
+package node
+
+import (
+	"log"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/raonismaneoto/CustomDHT/commons/grpc_api"
+	"github.com/raonismaneoto/CustomDHT/commons/helpers"
+	"github.com/raonismaneoto/CustomDHT/core/models"
+	"github.com/raonismaneoto/CustomDHT/core/router"
+	"github.com/raonismaneoto/CustomDHT/core/storage"
+)
+
+// overlay is the Router of a node along with what the node leaves to it of
+// the lookups of keys no virtual node of its process owns, and the checker
+// of its structure. The lookups of overlays other than Chord are run by
+// their router, the node only reading or writing at the owner it finds.
+type overlay interface {
+	router.Router
+	routeOwner(key int64, route Route) (*grpc_api.OwnerResponse, error)
+	routeQuery(key int64, route Route) (*grpc_api.QueryResponse, error)
+	// iterativeOwner resolves the owner of key from this node, the path
+	// having one hop per node asked.
+	iterativeOwner(key int64) (models.NodeRepresentation, []*grpc_api.Hop, error)
+	checkRing(report *grpc_api.CheckRingResponse)
+	// setId moves the node to id before it joins, its id being taken.
+	setId(id int64)
+}
+
+func republishInterval() int {
+	return helpers.EnvInt("REPUBLISH_INTERVAL", 1, 60)
+}
+
+// kademliaRouter is the Kademlia router plus the keys moving along with the
+// nodes: a node joining pulls the keys it is now the closest to, and every
+// owner copies its keys to their replica again from time to time, nodes
+// coming and going changing which one that is.
+type kademliaRouter struct {
+	*router.Kademlia
+	n *Node
+}
+
+func (k *kademliaRouter) Join(seed models.NodeRepresentation) error {
+	if err := k.n.resolveIdCollision(&seed); err != nil {
+		return err
+	}
+	if err := k.Kademlia.Join(seed); err != nil {
+		return err
+	}
+	if len(helpers.OrderedNamespaces()) > 0 {
+		log.Println("ordered namespaces are only balanced on the chord overlay")
+	}
+	k.n.pullCloserKeys()
+	return nil
+}
+
+func (k *kademliaRouter) setId(id int64) {
+	k.n.id = id
+	k.Kademlia.SetId(id)
+}
+
+func (k *kademliaRouter) Start() {
+	k.Kademlia.Start()
+	helpers.PeriodicInvocation(k.n.republish, republishInterval())
+}
+
+func (k *kademliaRouter) Leave() error {
+	return k.n.overlayLeave()
+}
+
+func (k *kademliaRouter) routeOwner(key int64, route Route) (*grpc_api.OwnerResponse, error) {
+	owner, rounds, err := k.Owner(key)
+	if err != nil {
+		return nil, err
+	}
+	return &grpc_api.OwnerResponse{
+		OwnerNodeId:       owner.Id,
+		OwnerNodeEndpoint: owner.Address,
+		Hops:              route.Hops + rounds,
+	}, nil
+}
+
+func (k *kademliaRouter) routeQuery(key int64, route Route) (*grpc_api.QueryResponse, error) {
+	n := k.n
+	acks, err := Acks(route.Consistency)
+	if err != nil {
+		return &grpc_api.QueryResponse{}, err
+	}
+	owner, rounds, err := n.router.Owner(key)
+	if err != nil {
+		return &grpc_api.QueryResponse{}, err
+	}
+	if owner.Address == n.address {
+		response, err := n.readOwned(key, acks)
+		if err != nil {
+			return &grpc_api.QueryResponse{}, err
+		}
+		response.Hops = route.Hops + rounds
+		return response, nil
+	}
+
+	log.Println("key " + strconv.FormatInt(key, 10) + " is owned by " + owner.Address)
+	var response *grpc_api.QueryResponse
+	if acks > 1 {
+		// the owner reads the copies of its replicas along with its own
+		response, err = n.client.ForwardQuery(owner.Address, &grpc_api.QueryRequest{Key: key, Direct: true, Consistency: route.Consistency})
+	} else {
+		response, err = n.client.ReadLocal(owner.Address, key, "")
+	}
+	if response.ResponsibleNodeEndpoint == "" {
+		// the owner did not answer, the nodes closest to the key after it
+		// hold its replicas
+		candidates := append(n.router.Closest(key, 0, models.NodeRepresentation{}), n.self())
+		sort.Slice(candidates, func(i, j int) bool { return candidates[i].Id^key < candidates[j].Id^key })
+		for i, candidate := range candidates {
+			if candidate.Address == owner.Address {
+				candidates = candidates[i+1:]
+				break
+			}
+		}
+		replica, ok := n.queryReplicas(key, owner, candidates, acks)
+		if !ok && err != nil {
+			return response, err
+		}
+		if ok {
+			response = replica
+		}
+	}
+	response.Hops = route.Hops + rounds + 1
+	return response, nil
+}
+
+// iterativeOwner is Owner, Kademlia looking keys up iteratively already.
+func (k *kademliaRouter) iterativeOwner(key int64) (models.NodeRepresentation, []*grpc_api.Hop, error) {
+	start := time.Now()
+	path := []*grpc_api.Hop{k.n.hop(start)}
+	owner, rounds, err := k.Owner(key)
+	path[0].ElapsedMicros = time.Since(start).Microseconds()
+	if err == nil {
+		k.n.observeLookup(start, rounds)
+	}
+	return owner, path, err
+}
+
+func (k *kademliaRouter) checkRing(report *grpc_api.CheckRingResponse) {
+	report.Problems = append(report.Problems, "the ring checker only applies to the chord overlay, this node runs "+k.Name())
+}
+
+// FindNode lists the nodes this one knows closest to key, as its overlay
+// measures it, learning of sender along the way.
+func (n *Node) FindNode(key int64, count int, sender models.NodeRepresentation) []models.NodeRepresentation {
+	return n.router.Closest(key, count, sender)
+}
+
+// pullCloserKeys copies over the keys of the nodes around this one that it
+// is now closer to than they are.
+func (n *Node) pullCloserKeys() {
+	for _, member := range n.router.Closest(n.id, 0, models.NodeRepresentation{}) {
+		keys, err := n.client.Keys(member.Address, member.Id, member.Id)
+		if err != nil {
+			log.Println("unable to list the keys of " + member.Address + ": " + err.Error())
+			continue
+		}
+		pulled := 0
+		for i, key := range keys.Keys {
+			if n.id^key >= member.Id^key || !n.mustKeyBeInNode(key) {
+				continue
+			}
+			response := n.client.QueryLocal(member.Address, key, "")
+			if len(response.Data) == 0 {
+				continue
+			}
+			if _, err := n.storage.PutIfNewer(storage.Entry{Key: key, Data: response.Data, Version: listedVersion(keys, i)}); err != nil {
+				log.Println(err.Error())
+				continue
+			}
+			pulled++
+		}
+		if pulled > 0 {
+			log.Println("pulled " + strconv.Itoa(pulled) + " keys from " + member.Address)
+		}
+	}
+}
+
+// republish copies every key this node owns to its replicas, which nodes
+// coming and going change.
+func (n *Node) republish() {
+	if n.left.Load() {
+		return
+	}
+	for _, key := range n.storage.Keys() {
+		if !n.mustKeyBeInNode(key) {
+			continue
+		}
+		data, err := n.storage.Read(key)
+		if err != nil {
+			continue
+		}
+		name, _ := n.storage.Name(key)
+		n.replicateEntry(key, name, data)
+	}
+}
+
+// overlayLeave hands every owned key to the node closest to it after this
+// one, which owns it once this node is gone.
+func (n *Node) overlayLeave() error {
+	byTarget := make(map[string][]*grpc_api.HandoffEntry)
+	for _, key := range n.storage.Keys() {
+		if !n.mustKeyBeInNode(key) {
+			continue
+		}
+		// the node owning the key next, replication on or not
+		targets := n.router.ReplicaTargets(key, 1)
+		if len(targets) == 0 {
+			continue
+		}
+		target := targets[0]
+		data, err := n.storage.Read(key)
+		if err != nil {
+			continue
+		}
+		name, _ := n.storage.Name(key)
+		byTarget[target.Address] = append(byTarget[target.Address], &grpc_api.HandoffEntry{Key: key, Data: data, Name: name, Replica: true, Version: n.storage.Version(key)})
+	}
+
+	n.left.Store(true)
+	n.setHealth(HealthLeft)
+	n.router.Stop()
+	for address, entries := range byTarget {
+		log.Println("leaving, handing " + strconv.Itoa(len(entries)) + " keys off to " + address)
+		if err := n.client.Handoff(address, entries); err != nil {
+			log.Println("unable to hand keys off to " + address + ": " + err.Error())
+			return err
+		}
+	}
+	log.Println("left the overlay")
+	return nil
+}
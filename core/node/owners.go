@@ -0,0 +1,54 @@
+package node
+
+import (
+	"log"
+
+	"github.com/raonismaneoto/CustomDHT/commons/helpers"
+	"github.com/raonismaneoto/CustomDHT/core/models"
+)
+
+// ownerCacheSize is how many owner ranges a node keeps, 0 turning the cache
+// off.
+func ownerCacheSize() int {
+	return helpers.EnvInt("OWNER_CACHE_SIZE", 0, 1024)
+}
+
+// Owns tells whether this node, or a virtual node of its process, is
+// responsible for key.
+func (n *Node) Owns(key int64) bool {
+	return n.mustKeyBeInNode(key) || n.localOwner(key) != nil
+}
+
+// cachedOwner is the owner of key as last learned, one hop nodes having the
+// whole ring to route from instead.
+func (n *Node) cachedOwner(key int64) (models.NodeRepresentation, bool) {
+	if isOneHop() {
+		return models.NodeRepresentation{}, false
+	}
+	owner, ok := n.owners.Get(key)
+	if ok && owner.Address == n.address {
+		n.owners.Invalidate(n.address)
+		return models.NodeRepresentation{}, false
+	}
+	return owner, ok
+}
+
+// learnOwner caches owner for the range it owns, or for key alone when the
+// range is not known.
+func (n *Node) learnOwner(key int64, owner models.NodeRepresentation, start int64, known bool) {
+	if owner.Address == "" || owner.Address == n.address {
+		return
+	}
+	end := owner.Id
+	if !known {
+		start, end = key-1, key
+	}
+	n.owners.Put(start, end, owner)
+}
+
+// dropOwner forgets the ranges cached for an owner that turned a direct
+// request down or did not answer it.
+func (n *Node) dropOwner(address string, err error) {
+	log.Println("cached owner " + address + " failed: " + err.Error())
+	n.owners.Invalidate(address)
+}
@@ -0,0 +1,104 @@
+package node
+
+import (
+	"sync"
+	"time"
+
+	"github.com/raonismaneoto/CustomDHT/commons/helpers"
+	"github.com/raonismaneoto/CustomDHT/core/metrics"
+	"github.com/raonismaneoto/CustomDHT/core/models"
+	"github.com/raonismaneoto/CustomDHT/core/ring"
+)
+
+// latencies is a moving average of the round trip time to each node pinged,
+// measured in the background so picking a finger never waits on a ping.
+type latencies struct {
+	mu        sync.Mutex
+	rtt       map[string]time.Duration
+	measuring map[string]bool
+}
+
+// pnsCandidates is how many nodes of a finger interval are measured to
+// pick the finger from, 1 choosing by id alone.
+func pnsCandidates() int {
+	return helpers.EnvInt("PNS_CANDIDATES", 1, 4)
+}
+
+// measure pings address in the background and adds the round trip time to
+// its average, which is dropped when the ping fails.
+func (n *Node) measure(address string) {
+	n.latencies.mu.Lock()
+	if n.latencies.rtt == nil {
+		n.latencies.rtt = make(map[string]time.Duration)
+		n.latencies.measuring = make(map[string]bool)
+	}
+	if n.latencies.measuring[address] {
+		n.latencies.mu.Unlock()
+		return
+	}
+	n.latencies.measuring[address] = true
+	n.latencies.mu.Unlock()
+
+	go func() {
+		start := time.Now()
+		_, err := n.client.Ping(address)
+		sample := time.Since(start)
+
+		n.latencies.mu.Lock()
+		defer n.latencies.mu.Unlock()
+		delete(n.latencies.measuring, address)
+		if err != nil {
+			delete(n.latencies.rtt, address)
+			return
+		}
+		if average, ok := n.latencies.rtt[address]; ok {
+			sample = (7*average + sample) / 8
+		}
+		n.latencies.rtt[address] = sample
+	}()
+}
+
+// rtt is the average round trip time to address, if it was measured.
+func (n *Node) rtt(address string) (time.Duration, bool) {
+	n.latencies.mu.Lock()
+	defer n.latencies.mu.Unlock()
+	rtt, ok := n.latencies.rtt[address]
+	return rtt, ok
+}
+
+// proximateFinger picks finger i among the nodes in [start(i), start(i+1)),
+// any of them keeping lookups within log n hops, as the one with the lowest
+// round trip time. owner, the successor of start(i), is the first candidate
+// and the nodes following it in its successor list are the others. The
+// round trip times are the ones measured so far, the candidates being
+// measured again in the background for the next round.
+func (n *Node) proximateFinger(i int, owner models.NodeRepresentation) models.NodeRepresentation {
+	count := pnsCandidates()
+	id, m := n.position()
+	if count < 2 || owner.Address == n.address || !ring.InFingerInterval(id, i, m, owner.Id) {
+		return owner
+	}
+
+	var following []models.NodeRepresentation
+	if successors, err := n.client.SuccessorList(owner.Address); err == nil {
+		for _, entry := range successors.Successors {
+			following = append(following, models.NodeRepresentation{Id: entry.Id, Address: entry.Endpoint})
+		}
+	}
+	candidates := ring.FingerCandidates(models.NodeRepresentation{Id: id, Address: n.address}, i, m, owner, following, count)
+	for _, candidate := range candidates {
+		n.measure(candidate.Address)
+	}
+
+	return ring.ProximateFinger(candidates, n.rtt)
+}
+
+// observeLookup adds a lookup started at this node to the hop latency
+// average, lookups answered without leaving the node having no hops.
+func (n *Node) observeLookup(start time.Time, hops int32) {
+	if hops < 1 {
+		return
+	}
+	metrics.Add(metrics.LookupMicros, time.Since(start).Microseconds())
+	metrics.Add(metrics.LookupHops, int64(hops))
+}
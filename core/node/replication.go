@@ -0,0 +1,278 @@
+package node
+
+import (
+	"errors"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/raonismaneoto/CustomDHT/commons/grpc_api"
+	"github.com/raonismaneoto/CustomDHT/commons/helpers"
+	"github.com/raonismaneoto/CustomDHT/core/metrics"
+	"github.com/raonismaneoto/CustomDHT/core/models"
+	"github.com/raonismaneoto/CustomDHT/core/ring"
+)
+
+// replicationFactor is how many copies of every key the ring keeps, the one
+// of the owner included. 1 turns replication off.
+func replicationFactor() int {
+	return helpers.EnvInt("REPLICATION_FACTOR", 1, 2)
+}
+
+func (n *Node) replicaTargets(key int64) []models.NodeRepresentation {
+	return n.router.ReplicaTargets(key, replicationFactor()-1)
+}
+
+// copyWrite is a write of the copy of a key on a replica. Without Replace it
+// is a write the owner just took, appended to the copy on disk like it was
+// to the owner's. Replace puts a whole value, or ordered bucket, over the
+// copy, Deleted removes it.
+type copyWrite struct {
+	Key     int64
+	Name    string
+	Data    []byte
+	Version int64
+	Replace bool
+	Deleted bool
+}
+
+// writeCopy sends write to the replica at address, which stores it before
+// answering when sync is set. Replicas suspected to be down are not waited
+// on, the write is left for hinted handoff.
+func (n *Node) writeCopy(address string, write copyWrite, sync bool) error {
+	if n.isSuspected(address) {
+		return errors.New("the replica is suspected to be down")
+	}
+	switch {
+	case write.Deleted:
+		return n.client.RepDelete(address, write.Key, write.Version)
+	case write.Replace:
+		_, err := n.client.RepPut(address, write.Key, write.Name, write.Data, write.Version)
+		return err
+	case sync:
+		return n.client.SyncRepSave(address, write.Key, write.Name, write.Data, write.Version)
+	}
+	_, err := n.client.RepSave(address, write.Key, write.Name, write.Data, write.Version)
+	return err
+}
+
+// replicate sends write to each of the replicas of its key. Failures are
+// logged, counted and hinted.
+func (n *Node) replicate(write copyWrite) {
+	for _, target := range n.replicaTargets(write.Key) {
+		if err := n.writeCopy(target.Address, write, false); err != nil {
+			n.replicationFailed(target.Address, write, err)
+		}
+	}
+}
+
+// replicateLater queues write for the replicas of its key, for writes whose
+// caller does not wait on them. The queue keeps the writes in order, the
+// appends of a key reaching the copies as they reached the owner.
+func (n *Node) replicateLater(write copyWrite) {
+	n.copyBuffer <- write
+}
+
+func (n *Node) sendCopies() {
+	for write := range n.copyBuffer {
+		n.replicate(write)
+	}
+}
+
+func (n *Node) replicationFailed(address string, write copyWrite, err error) {
+	log.Println("unable to replicate key " + strconv.FormatInt(write.Key, 10) + " to " + address + ": " + err.Error())
+	metrics.Inc(metrics.ReplicationFailures)
+	n.storeHint(address, write)
+}
+
+// replicateEntry puts the whole value data of key over its copies.
+func (n *Node) replicateEntry(key int64, name string, data []byte) {
+	n.replicate(copyWrite{Key: key, Name: name, Data: data, Version: n.storage.Version(key), Replace: true})
+}
+
+// replicateGrowth copies the keys a new predecessor, further back than
+// previous, adds to the range of this node over to its replicas.
+func (n *Node) replicateGrowth(previous, predecessor models.NodeRepresentation) {
+	if previous.Address == "" || previous.Address == predecessor.Address || ring.InOpenInterval(predecessor.Id, previous.Id, n.Id()) {
+		return
+	}
+	go n.replicateRange(predecessor.Id, previous.Id)
+}
+
+// pickReplicas takes, out of the nodes following owner in candidates, the
+// first count on hosts other than the one of owner and of each other.
+func pickReplicas(owner models.NodeRepresentation, candidates []models.NodeRepresentation, count int) []models.NodeRepresentation {
+	hosts := map[string]bool{owner.Host(): true}
+	var replicas []models.NodeRepresentation
+	for _, candidate := range candidates {
+		if len(replicas) >= count || candidate.Address == "" || candidate.Address == owner.Address {
+			break
+		}
+		if !hosts[candidate.Host()] {
+			hosts[candidate.Host()] = true
+			replicas = append(replicas, candidate)
+		}
+	}
+	return replicas
+}
+
+func successorNodes(response *grpc_api.SuccessorListResponse) []models.NodeRepresentation {
+	var nodes []models.NodeRepresentation
+	for _, entry := range response.Successors {
+		nodes = append(nodes, models.NodeRepresentation{Id: entry.Id, Address: entry.Endpoint})
+	}
+	return nodes
+}
+
+// walkReplicas finds the count successors of this node on distinct hosts,
+// going past the successor list when virtual nodes sharing a host fill it.
+func (n *Node) walkReplicas(count int) []models.NodeRepresentation {
+	candidates := n.SuccessorList()
+	replicas := pickReplicas(n.self(), candidates, count)
+	for i := 0; len(replicas) < count && len(candidates) >= successorListLength() && i <= len(n.siblings)+count; i++ {
+		last := candidates[len(candidates)-1]
+		response, err := n.client.SuccessorList(last.Address)
+		if err != nil {
+			log.Println("unable to walk the ring looking for replica hosts: " + err.Error())
+			break
+		}
+		more := successorNodes(response)
+		if len(more) == 0 {
+			break
+		}
+		candidates = append(candidates, more...)
+		replicas = pickReplicas(n.self(), candidates, count)
+	}
+	return replicas
+}
+
+// chordReplicaTargets are the successors the replicas of the range of this
+// node go to, as last placed.
+func (n *Node) chordReplicaTargets(count int) []models.NodeRepresentation {
+	n.replicasMu.Lock()
+	replicas, placed := n.replicas, n.replicasPlaced
+	n.replicasMu.Unlock()
+	if !placed {
+		replicas = n.walkReplicas(replicationFactor() - 1)
+	}
+	if len(replicas) > count {
+		replicas = replicas[:count]
+	}
+	return replicas
+}
+
+// placeReplicas works out again which successors hold the replicas of this
+// node, the ring having changed since, and copies the owned keys over to
+// the ones that did not hold them before.
+func (n *Node) placeReplicas() {
+	if n.left.Load() || replicationFactor() < 2 {
+		return
+	}
+	replicas := n.walkReplicas(replicationFactor() - 1)
+	n.replicasMu.Lock()
+	previous := n.replicas
+	n.replicas, n.replicasPlaced = replicas, true
+	n.replicasMu.Unlock()
+
+	held := make(map[string]bool)
+	for _, replica := range previous {
+		held[replica.Address] = true
+	}
+	var added, addresses []string
+	for _, replica := range replicas {
+		addresses = append(addresses, replica.Address)
+		if !held[replica.Address] {
+			added = append(added, replica.Address)
+		}
+	}
+	if len(added) == 0 && len(replicas) == len(previous) {
+		return
+	}
+	log.Println("the replicas of this node are now on " + strings.Join(addresses, ", "))
+
+	for _, key := range n.storage.Keys() {
+		if !n.mustKeyBeInNode(key) {
+			continue
+		}
+		data, err := n.storage.Read(key)
+		if err != nil {
+			continue
+		}
+		name, _ := n.storage.Name(key)
+		write := copyWrite{Key: key, Name: name, Data: data, Version: n.storage.Version(key), Replace: true}
+		for _, address := range added {
+			if err := n.writeCopy(address, write, false); err != nil {
+				n.replicationFailed(address, write, err)
+			}
+		}
+	}
+}
+
+// holdsReplicaOf tells whether this node, or a virtual node sharing its
+// storage, is one of the replicas of owner. When the successor list of
+// owner is filled by virtual nodes sharing a host before reaching all of
+// them, it is taken to be.
+func (n *Node) holdsReplicaOf(owner models.NodeRepresentation) bool {
+	response, err := n.client.SuccessorList(owner.Address)
+	if err != nil {
+		return true
+	}
+	candidates := successorNodes(response)
+	replicas := pickReplicas(owner, candidates, replicationFactor()-1)
+	for _, replica := range replicas {
+		if n.isLocal(replica.Address) {
+			return true
+		}
+	}
+	return len(replicas) < replicationFactor()-1 && len(candidates) >= successorListLength()
+}
+
+// queryReplicas reads key from the replicas of owner, picked out of
+// candidates, when owner does not answer. The most recent of the copies of
+// acks of them is answered with.
+func (n *Node) queryReplicas(key int64, owner models.NodeRepresentation, candidates []models.NodeRepresentation, acks int) (*grpc_api.QueryResponse, bool) {
+	var read []copyRead
+	for _, replica := range pickReplicas(owner, candidates, replicationFactor()-1) {
+		var response *grpc_api.QueryResponse
+		if replica.Address == n.address {
+			local := n.QueryLocal(key, "")
+			response = &local
+		} else {
+			response = n.client.QueryLocal(replica.Address, key, "")
+		}
+		if response.ResponsibleNodeEndpoint == "" {
+			continue
+		}
+		read = append(read, copyRead{address: replica.Address, response: response})
+		// a single copy is taken once one holding the key is found
+		if len(read) >= acks && (acks > 1 || len(response.Data) > 0) {
+			break
+		}
+	}
+	if len(read) < acks {
+		return nil, false
+	}
+	newest := newestCopy(read)
+	if len(newest.Data) == 0 {
+		return nil, false
+	}
+	log.Println("owner " + owner.Address + " is unreachable, read key " + strconv.FormatInt(key, 10) + " from " + strconv.Itoa(len(read)) + " of its replicas")
+	metrics.Inc(metrics.ReplicaReads)
+	return newest, true
+}
+
+// successorReplicas reads key, owned by the successor of this node, from
+// the nodes after it holding its replicas.
+func (n *Node) successorReplicas(key int64, route Route) (*grpc_api.QueryResponse, bool) {
+	successors := n.SuccessorList()
+	candidates := append(append([]models.NodeRepresentation{}, successors[1:]...), n.self())
+	acks, err := Acks(route.Consistency)
+	if err != nil {
+		return nil, false
+	}
+	response, ok := n.queryReplicas(key, successors[0], candidates, acks)
+	if ok {
+		response.Hops = route.Hops + 1
+	}
+	return response, ok
+}
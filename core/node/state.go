@@ -0,0 +1,72 @@
+package node
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"time"
+
+	"github.com/raonismaneoto/CustomDHT/commons/grpc_api"
+	"github.com/raonismaneoto/CustomDHT/commons/helpers"
+	"github.com/raonismaneoto/CustomDHT/core/models"
+)
+
+// NodeVersion is the version the node reports, set at build time with
+// -ldflags "-X github.com/raonismaneoto/CustomDHT/core/node.NodeVersion=<version>".
+var NodeVersion = "dev"
+
+// stateDumpInterval is how often the state is written to ./state-<time>.json,
+// 0, the default, turning the files off.
+func stateDumpInterval() int {
+	return helpers.EnvInt("STATE_DUMP_INTERVAL", 0, 0)
+}
+
+func nodeInfo(node models.NodeRepresentation) *grpc_api.NodeInfo {
+	return &grpc_api.NodeInfo{Id: node.Id, Endpoint: node.Address}
+}
+
+// State is what the node knows of the ring and holds. The owned range is
+// (rangeStart, rangeEnd] on the Chord ring, the Kademlia overlay having none,
+// and the key count leaves out the replicas and the keys of the other
+// virtual nodes sharing the storage. Pending hints are counted per replica.
+func (n *Node) State() *grpc_api.NodeStateResponse {
+	pred, _ := n.chord.neighbours()
+	state := &grpc_api.NodeStateResponse{
+		Id:          n.Id(),
+		Endpoint:    n.address,
+		Predecessor: nodeInfo(pred),
+		StorageType: n.storage.Type.String(),
+		Version:     NodeVersion,
+		Overlay:     n.router.Name(),
+		Status:      n.Health().Status,
+	}
+	for _, succ := range n.SuccessorList() {
+		state.Successors = append(state.Successors, nodeInfo(succ))
+	}
+	for _, finger := range n.Fingers() {
+		state.Fingers = append(state.Fingers, nodeInfo(finger))
+	}
+	if start, known := n.router.OwnedRange(); known {
+		state.RangeStart, state.RangeEnd = start, state.Id
+	}
+	for _, key := range n.storage.Keys() {
+		if n.mustKeyBeInNode(key) {
+			state.KeyCount++
+		}
+	}
+	state.UptimeSeconds = int64(time.Since(n.started).Seconds())
+	state.PendingHints = n.PendingHints()
+	return state
+}
+
+func (n *Node) dumpState() {
+	content, err := json.MarshalIndent(n.State(), "", "  ")
+	if err != nil {
+		log.Println("unable to encode the node state: " + err.Error())
+		return
+	}
+	if err := ioutil.WriteFile("./state-"+fmt.Sprint(time.Now().Unix())+".json", content, 0644); err != nil {
+		log.Println("unable to write the node state: " + err.Error())
+	}
+}
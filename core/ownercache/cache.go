@@ -0,0 +1,90 @@
+package ownercache
+
+import (
+	"container/list"
+	"sync"
+
+	"github.com/raonismaneoto/CustomDHT/core/metrics"
+	"github.com/raonismaneoto/CustomDHT/core/models"
+	"github.com/raonismaneoto/CustomDHT/core/ring"
+)
+
+type entry struct {
+	start int64
+	end   int64
+	owner models.NodeRepresentation
+}
+
+// Cache maps ranges (start, end] of the ring to the node owning them, the
+// least recently used range going first once size of them are held. A nil
+// Cache holds nothing.
+type Cache struct {
+	mu      sync.Mutex
+	size    int
+	entries *list.List
+}
+
+func New(size int) *Cache {
+	if size < 1 {
+		return nil
+	}
+	return &Cache{size: size, entries: list.New()}
+}
+
+// Get is the cached owner of key, if any.
+func (c *Cache) Get(key int64) (models.NodeRepresentation, bool) {
+	if c == nil {
+		return models.NodeRepresentation{}, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for e := c.entries.Front(); e != nil; e = e.Next() {
+		cached := e.Value.(entry)
+		if ring.InHalfOpenInterval(key, cached.start, cached.end) {
+			c.entries.MoveToFront(e)
+			metrics.Inc(metrics.OwnerCacheHits)
+			return cached.owner, true
+		}
+	}
+	metrics.Inc(metrics.OwnerCacheMisses)
+	return models.NodeRepresentation{}, false
+}
+
+// Put caches owner as the owner of (start, end], replacing the ranges it
+// overlaps, which are out of date.
+func (c *Cache) Put(start, end int64, owner models.NodeRepresentation) {
+	if c == nil || owner.Address == "" {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for e := c.entries.Front(); e != nil; {
+		next := e.Next()
+		cached := e.Value.(entry)
+		if ring.InHalfOpenInterval(cached.end, start, end) || ring.InHalfOpenInterval(end, cached.start, cached.end) {
+			c.entries.Remove(e)
+		}
+		e = next
+	}
+	c.entries.PushFront(entry{start: start, end: end, owner: owner})
+	for c.entries.Len() > c.size {
+		c.entries.Remove(c.entries.Back())
+	}
+}
+
+// Invalidate drops every range cached as owned by address.
+func (c *Cache) Invalidate(address string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for e := c.entries.Front(); e != nil; {
+		next := e.Next()
+		if e.Value.(entry).owner.Address == address {
+			c.entries.Remove(e)
+			metrics.Inc(metrics.OwnerCacheInvalidations)
+		}
+		e = next
+	}
+}
@@ -0,0 +1,142 @@
+package ring
+
+import (
+	"sort"
+	"time"
+
+	"github.com/raonismaneoto/CustomDHT/core/models"
+)
+
+func Size(m int) int64 {
+	return int64(1) << uint(m)
+}
+
+func Distance(i int64, j int64, m int) int64 {
+	if j >= i {
+		return j - i
+	}
+	return Size(m) - i + j
+}
+
+// InOpenInterval tells whether key lies in (start, end) walking the ring
+// clockwise. When start and end are the same the interval is the whole ring
+// but that point.
+func InOpenInterval(key int64, start int64, end int64) bool {
+	if start < end {
+		return key > start && key < end
+	}
+	if start == end {
+		return key != start
+	}
+	return key > start || key < end
+}
+
+// InHalfOpenInterval tells whether key lies in (start, end]. When start and
+// end are the same the interval is the whole ring.
+func InHalfOpenInterval(key int64, start int64, end int64) bool {
+	return key == end || InOpenInterval(key, start, end)
+}
+
+// FingerStart is the first id finger i of node id is responsible for,
+// id + 2^i.
+func FingerStart(id int64, i int, m int) int64 {
+	return (id + (int64(1) << uint(i))) % Size(m)
+}
+
+// InFingerInterval tells whether key lies in [start(i), start(i+1)), the
+// interval finger i of node id may point into.
+func InFingerInterval(id int64, i int, m int, key int64) bool {
+	start := FingerStart(id, i, m)
+	return Distance(start, key, m) < Distance(start, FingerStart(id, i+1, m), m)
+}
+
+// FingerCandidates are the nodes finger i of self may point to for
+// proximity: owner, the successor of start(i), and the nodes following it,
+// up to count of them and as long as they stay in the finger interval.
+func FingerCandidates(self models.NodeRepresentation, i int, m int, owner models.NodeRepresentation, following []models.NodeRepresentation, count int) []models.NodeRepresentation {
+	candidates := []models.NodeRepresentation{owner}
+	if owner.Address == self.Address || !InFingerInterval(self.Id, i, m, owner.Id) {
+		return candidates
+	}
+	for _, node := range following {
+		if len(candidates) >= count || node.Address == self.Address || !InFingerInterval(self.Id, i, m, node.Id) {
+			break
+		}
+		candidates = append(candidates, node)
+	}
+	return candidates
+}
+
+// ProximateFinger is the candidate with the lowest round trip time, rtt
+// telling it for the nodes measured. The first candidate is kept when none
+// was.
+func ProximateFinger(candidates []models.NodeRepresentation, rtt func(address string) (time.Duration, bool)) models.NodeRepresentation {
+	best := candidates[0]
+	var bestRtt time.Duration
+	for _, candidate := range candidates {
+		sample, ok := rtt(candidate.Address)
+		if !ok {
+			continue
+		}
+		if bestRtt == 0 || sample < bestRtt {
+			best, bestRtt = candidate, sample
+		}
+	}
+	return best
+}
+
+// ClosestPreceding is the node among the fingers that most closely precedes
+// key, or self when none of them does.
+func ClosestPreceding(self models.NodeRepresentation, fingers []models.NodeRepresentation, key int64) models.NodeRepresentation {
+	for i := len(fingers) - 1; i >= 0; i-- {
+		finger := fingers[i]
+		if finger.Address == "" || finger.Address == self.Address {
+			continue
+		}
+		if InOpenInterval(finger.Id, self.Id, key) {
+			return finger
+		}
+	}
+	return self
+}
+
+// ClosestPrecedingN returns up to count distinct nodes lying in (self, key),
+// the closest to key first. Iterative lookups use them as alternative hops.
+func ClosestPrecedingN(self models.NodeRepresentation, nodes []models.NodeRepresentation, key int64, count int, m int) []models.NodeRepresentation {
+	seen := make(map[string]bool)
+	var candidates []models.NodeRepresentation
+	for _, node := range nodes {
+		if node.Address == "" || node.Address == self.Address || seen[node.Address] {
+			continue
+		}
+		if InOpenInterval(node.Id, self.Id, key) {
+			seen[node.Address] = true
+			candidates = append(candidates, node)
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return Distance(candidates[i].Id, key, m) < Distance(candidates[j].Id, key, m)
+	})
+	if len(candidates) > count {
+		candidates = candidates[:count]
+	}
+	return candidates
+}
+
+// NextHop is a single step of a lookup for key at self. When done is true
+// the returned node is the owner of key, otherwise it is the node to ask
+// next.
+func NextHop(self models.NodeRepresentation, succ models.NodeRepresentation, fingers []models.NodeRepresentation, key int64) (models.NodeRepresentation, bool) {
+	if succ.Address == "" {
+		return self, true
+	}
+	if InHalfOpenInterval(key, self.Id, succ.Id) {
+		return succ, true
+	}
+
+	next := ClosestPreceding(self, fingers, key)
+	if next.Address == self.Address {
+		return succ, true
+	}
+	return next, false
+}
@@ -0,0 +1,311 @@
+package ring
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/raonismaneoto/CustomDHT/core/models"
+)
+
+func node(id int64) models.NodeRepresentation {
+	return models.NodeRepresentation{Id: id, Address: fmt.Sprintf("node-%v", id)}
+}
+
+func TestFingerStart(t *testing.T) {
+	cases := []struct {
+		id    int64
+		i     int
+		m     int
+		start int64
+	}{
+		{0, 0, 4, 1},
+		{0, 3, 4, 8},
+		{5, 2, 4, 9},
+		{14, 1, 4, 0},
+		{15, 3, 4, 7},
+		{100, 31, 32, 100 + 1<<31},
+		{1<<32 - 1, 0, 32, 0},
+	}
+	for _, c := range cases {
+		if start := FingerStart(c.id, c.i, c.m); start != c.start {
+			t.Errorf("FingerStart(%v, %v, %v) = %v, expected %v", c.id, c.i, c.m, start, c.start)
+		}
+	}
+}
+
+func TestNextHop(t *testing.T) {
+	self, succ := node(10), node(20)
+	fingers := []models.NodeRepresentation{succ, node(30), node(50), node(90)}
+	cases := []struct {
+		key  int64
+		next int64
+		done bool
+	}{
+		// the successor owns (self, succ]
+		{15, 20, true},
+		{20, 20, true},
+		// the closest preceding finger takes the lookup on
+		{40, 30, false},
+		{60, 50, false},
+		{95, 90, false},
+		{5, 90, false},
+		{10, 90, false},
+	}
+	for _, c := range cases {
+		next, done := NextHop(self, succ, fingers, c.key)
+		if next.Id != c.next || done != c.done {
+			t.Errorf("NextHop for %v went to %v, done %v, expected %v, done %v", c.key, next.Id, done, c.next, c.done)
+		}
+	}
+
+	// a node alone on the ring owns every key
+	if next, done := NextHop(self, models.NodeRepresentation{}, nil, 40); next != self || !done {
+		t.Errorf("NextHop without a successor went to %v, done %v", next.Id, done)
+	}
+	// with no finger past the successor the lookup goes on there
+	if next, done := NextHop(self, succ, []models.NodeRepresentation{succ}, 40); next != succ || done {
+		t.Errorf("NextHop with the successor alone went to %v, done %v", next.Id, done)
+	}
+}
+
+func TestFingerCandidates(t *testing.T) {
+	// finger 3 of node 0 on a ring of 2^6 ids spans [8, 16)
+	self := node(0)
+	following := []models.NodeRepresentation{node(11), node(15), node(16), node(20)}
+	cases := []struct {
+		owner      models.NodeRepresentation
+		following  []models.NodeRepresentation
+		count      int
+		candidates []int64
+	}{
+		{node(9), following, 4, []int64{9, 11, 15}},
+		{node(9), following, 2, []int64{9, 11}},
+		{node(9), following, 1, []int64{9}},
+		{node(9), nil, 4, []int64{9}},
+		// an owner past the interval is the only node the finger may point to
+		{node(16), following[3:], 4, []int64{16}},
+		// the walk stops back at self
+		{node(9), []models.NodeRepresentation{self, node(11)}, 4, []int64{9}},
+		{self, following, 4, []int64{0}},
+	}
+	for _, c := range cases {
+		candidates := FingerCandidates(self, 3, 6, c.owner, c.following, c.count)
+		ids := make([]int64, len(candidates))
+		for i, candidate := range candidates {
+			ids[i] = candidate.Id
+		}
+		if fmt.Sprint(ids) != fmt.Sprint(c.candidates) {
+			t.Errorf("FingerCandidates for owner %v and count %v = %v, expected %v", c.owner.Id, c.count, ids, c.candidates)
+		}
+	}
+}
+
+func TestProximateFinger(t *testing.T) {
+	candidates := []models.NodeRepresentation{node(9), node(11), node(15)}
+	cases := []struct {
+		rtts   map[int64]time.Duration
+		finger int64
+	}{
+		{map[int64]time.Duration{9: 30, 11: 10, 15: 20}, 11},
+		{map[int64]time.Duration{9: 30, 15: 20}, 15},
+		{map[int64]time.Duration{9: 10, 11: 10}, 9},
+		// nothing measured yet keeps the owner
+		{map[int64]time.Duration{}, 9},
+		{map[int64]time.Duration{11: 40}, 11},
+	}
+	for _, c := range cases {
+		rtt := func(address string) (time.Duration, bool) {
+			var id int64
+			fmt.Sscanf(address, "node-%d", &id)
+			sample, ok := c.rtts[id]
+			return sample, ok
+		}
+		if finger := ProximateFinger(candidates, rtt); finger.Id != c.finger {
+			t.Errorf("ProximateFinger with %v picked %v, expected %v", c.rtts, finger.Id, c.finger)
+		}
+	}
+}
+
+type simNode struct {
+	self        models.NodeRepresentation
+	predecessor models.NodeRepresentation
+	fingers     []models.NodeRepresentation
+	nextFinger  int
+	x, y        float64
+}
+
+// simRing is a Chord ring of many nodes routing through FingerStart and
+// NextHop the way the nodes do, without the network in between.
+type simRing struct {
+	m     int
+	pns   int
+	ids   []int64
+	nodes map[int64]*simNode
+}
+
+func newSimRing(r *rand.Rand, count int, m int, pns int) *simRing {
+	sim := &simRing{m: m, pns: pns, nodes: make(map[int64]*simNode)}
+	for len(sim.ids) < count {
+		id := r.Int63n(Size(m))
+		if _, ok := sim.nodes[id]; ok {
+			continue
+		}
+		// nodes sit on a plane, the distance between two of them being the
+		// latency of a hop
+		sim.nodes[id] = &simNode{self: node(id), x: r.Float64() * 100, y: r.Float64() * 100}
+		sim.ids = append(sim.ids, id)
+	}
+	sort.Slice(sim.ids, func(i, j int) bool { return sim.ids[i] < sim.ids[j] })
+
+	// nodes start knowing only their predecessor and successor, as after a
+	// join, and fix_fingers fills in the rest
+	for i, id := range sim.ids {
+		n := sim.nodes[id]
+		n.fingers = make([]models.NodeRepresentation, m)
+		n.fingers[0] = sim.nodes[sim.ids[(i+1)%len(sim.ids)]].self
+		n.predecessor = sim.nodes[sim.ids[(i+len(sim.ids)-1)%len(sim.ids)]].self
+	}
+	return sim
+}
+
+func (sim *simRing) successor(key int64) int64 {
+	i := sort.Search(len(sim.ids), func(i int) bool { return sim.ids[i] >= key })
+	return sim.ids[i%len(sim.ids)]
+}
+
+func (sim *simRing) lookup(from *simNode, key int64) (models.NodeRepresentation, int, bool) {
+	current := from
+	for hops := 0; hops <= 4*sim.m; hops++ {
+		if InHalfOpenInterval(key, current.predecessor.Id, current.self.Id) {
+			return current.self, hops, true
+		}
+		next, done := NextHop(current.self, current.fingers[0], current.fingers, key)
+		if done {
+			return next, hops + 1, true
+		}
+		current = sim.nodes[next.Id]
+	}
+	return models.NodeRepresentation{}, 0, false
+}
+
+func (sim *simRing) latency(a, b *simNode) float64 {
+	return math.Hypot(a.x-b.x, a.y-b.y)
+}
+
+func (sim *simRing) fixFingers(n *simNode) {
+	n.nextFinger++
+	if n.nextFinger >= sim.m {
+		n.nextFinger = 1
+	}
+	owner, _, ok := sim.lookup(n, FingerStart(n.self.Id, n.nextFinger, sim.m))
+	if ok {
+		n.fingers[n.nextFinger] = sim.proximateFinger(n, n.nextFinger, owner)
+	}
+}
+
+// proximateFinger picks finger i out of owner and the nodes following it
+// through FingerCandidates and ProximateFinger, as the nodes do, the
+// latencies being all measured.
+func (sim *simRing) proximateFinger(n *simNode, i int, owner models.NodeRepresentation) models.NodeRepresentation {
+	if sim.pns < 2 {
+		return owner
+	}
+	index := sort.Search(len(sim.ids), func(j int) bool { return sim.ids[j] >= owner.Id })
+	var following []models.NodeRepresentation
+	for j := 1; j < sim.pns && j < len(sim.ids); j++ {
+		following = append(following, sim.nodes[sim.ids[(index+j)%len(sim.ids)]].self)
+	}
+	candidates := FingerCandidates(n.self, i, sim.m, owner, following, sim.pns)
+	return ProximateFinger(candidates, func(address string) (time.Duration, bool) {
+		var id int64
+		fmt.Sscanf(address, "node-%d", &id)
+		return time.Duration(sim.latency(n, sim.nodes[id]) * float64(time.Millisecond)), true
+	})
+}
+
+// expectedFinger is the textbook finger i of n, the successor of start(i),
+// or with pns the closest to n of the first pns nodes inside its interval.
+func (sim *simRing) expectedFinger(n *simNode, i int) int64 {
+	start := FingerStart(n.self.Id, i, sim.m)
+	width := Distance(start, FingerStart(n.self.Id, i+1, sim.m), sim.m)
+	index := sort.Search(len(sim.ids), func(j int) bool { return sim.ids[j] >= start })
+	expected := sim.ids[index%len(sim.ids)]
+	for j := 1; j < sim.pns; j++ {
+		id := sim.ids[(index+j)%len(sim.ids)]
+		if id == n.self.Id || Distance(start, id, sim.m) >= width {
+			break
+		}
+		if sim.latency(n, sim.nodes[id]) < sim.latency(n, sim.nodes[expected]) {
+			expected = id
+		}
+	}
+	return expected
+}
+
+// TestChordRing checks the routing on rings of hundreds of nodes: the
+// fingers built by fix_fingers must be the textbook ones, or the closest
+// picks with pns, and every lookup must reach the right owner in O(log N)
+// hops.
+func TestChordRing(t *testing.T) {
+	cases := []struct {
+		nodes int
+		m     int
+		pns   int
+	}{
+		{200, 32, 1},
+		{500, 32, 1},
+		{500, 16, 1},
+		{500, 32, 4},
+	}
+	for _, c := range cases {
+		c := c
+		t.Run(fmt.Sprintf("nodes=%v,m=%v,pns=%v", c.nodes, c.m, c.pns), func(t *testing.T) {
+			r := rand.New(rand.NewSource(1))
+			sim := newSimRing(r, c.nodes, c.m, c.pns)
+			for round := 0; round < c.m; round++ {
+				for _, id := range sim.ids {
+					sim.fixFingers(sim.nodes[id])
+				}
+			}
+
+			for _, id := range sim.ids {
+				for i, finger := range sim.nodes[id].fingers {
+					// the first finger is the successor, kept by stabilize
+					expected := sim.successor(FingerStart(id, i, c.m))
+					if i > 0 {
+						expected = sim.expectedFinger(sim.nodes[id], i)
+					}
+					if finger.Id != expected {
+						t.Errorf("finger %v of %v points to %v, expected %v", i, id, finger.Id, expected)
+					}
+				}
+			}
+
+			lookups := 20 * c.nodes
+			allowed := 3 * int(math.Ceil(math.Log2(float64(c.nodes))))
+			totalHops, maxHops := 0, 0
+			for i := 0; i < lookups; i++ {
+				from := sim.nodes[sim.ids[r.Intn(len(sim.ids))]]
+				key := r.Int63n(Size(c.m))
+				owner, hops, ok := sim.lookup(from, key)
+				if !ok || owner.Id != sim.successor(key) {
+					t.Errorf("lookup of %v from %v reached %v, expected %v", key, from.self.Id, owner.Id, sim.successor(key))
+					continue
+				}
+				totalHops += hops
+				if hops > maxHops {
+					maxHops = hops
+				}
+			}
+
+			averageHops := float64(totalHops) / float64(lookups)
+			if maxHops > allowed || averageHops > math.Log2(float64(c.nodes)) {
+				t.Errorf("lookups took %.2f hops on average and %v at most, %v allowed", averageHops, maxHops, allowed)
+			}
+		})
+	}
+}
@@ -0,0 +1,343 @@
+package router
+
+import (
+	"errors"
+	"log"
+	"math/bits"
+	"math/rand"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/raonismaneoto/CustomDHT/commons/grpc_api"
+	"github.com/raonismaneoto/CustomDHT/commons/helpers"
+	"github.com/raonismaneoto/CustomDHT/core/client"
+	"github.com/raonismaneoto/CustomDHT/core/models"
+)
+
+// Kademlia places a key at the node whose id is closest to it by XOR. Each
+// node keeps one k-bucket per bit of the id, bucket i holding up to k nodes
+// whose distance has its highest bit at i, and looks keys up by asking the
+// alpha closest nodes it knows for closer ones until no closer ones show up.
+type Kademlia struct {
+	self    models.NodeRepresentation
+	m       int
+	k       int
+	alpha   int
+	timeout time.Duration
+	client  *client.Client
+
+	mu      sync.Mutex
+	buckets [][]models.NodeRepresentation
+	stop    chan struct{}
+}
+
+func NewKademlia(self models.NodeRepresentation, m int, c *client.Client) *Kademlia {
+	return &Kademlia{
+		self:    self,
+		m:       m,
+		k:       helpers.EnvInt("KADEMLIA_K", 1, 8),
+		alpha:   helpers.EnvInt("KADEMLIA_ALPHA", 1, 3),
+		timeout: time.Duration(helpers.EnvInt("KADEMLIA_TIMEOUT", 1, 2000)) * time.Millisecond,
+		client:  c,
+		buckets: make([][]models.NodeRepresentation, m),
+		stop:    make(chan struct{}),
+	}
+}
+
+func (kd *Kademlia) Name() string {
+	return KademliaOverlay
+}
+
+// Join learns the seed and the nodes it knows near this one, then fills the
+// buckets in with lookups of the node id and of an id in each bucket.
+func (kd *Kademlia) Join(seed models.NodeRepresentation) error {
+	response, err := kd.client.FindNode(seed.Address, kd.self.Id, kd.k, kd.self, kd.timeout)
+	if err != nil {
+		return err
+	}
+	for _, node := range append(response.Nodes, response.Responder) {
+		if node.GetId() == kd.self.Id && node.GetEndpoint() != kd.self.Address {
+			kd.reset()
+			return errors.New("node id " + strconv.FormatInt(kd.self.Id, 10) + " is already taken by " + node.GetEndpoint())
+		}
+		kd.touch(nodeOf(node))
+	}
+
+	kd.lookup(kd.self.Id)
+	kd.refresh()
+	return nil
+}
+
+// SetId moves the node to id. It is only called before Join, the buckets
+// being placed by the id.
+func (kd *Kademlia) SetId(id int64) {
+	kd.mu.Lock()
+	defer kd.mu.Unlock()
+	kd.self.Id = id
+}
+
+func (kd *Kademlia) reset() {
+	kd.mu.Lock()
+	defer kd.mu.Unlock()
+	kd.buckets = make([][]models.NodeRepresentation, kd.m)
+}
+
+func (kd *Kademlia) Start() {
+	helpers.PeriodicInvocation(func() {
+		select {
+		case <-kd.stop:
+		default:
+			kd.refresh()
+		}
+	}, helpers.EnvInt("KADEMLIA_REFRESH_INTERVAL", 1, 60))
+}
+
+func (kd *Kademlia) Stop() {
+	kd.mu.Lock()
+	defer kd.mu.Unlock()
+	select {
+	case <-kd.stop:
+	default:
+		close(kd.stop)
+	}
+}
+
+// Leave stops the refreshes, the other nodes dropping this one from their
+// buckets once it no longer answers.
+func (kd *Kademlia) Leave() error {
+	kd.Stop()
+	return nil
+}
+
+// refresh looks up a random id in the range of every bucket, which both
+// finds nodes for the buckets and drops the ones not answering.
+func (kd *Kademlia) refresh() {
+	for i := 0; i < kd.m; i++ {
+		id := kd.self.Id ^ (int64(1)<<uint(i) | rand.Int63n(int64(1)<<uint(i)))
+		kd.lookup(id)
+	}
+}
+
+func (kd *Kademlia) Owner(key int64) (models.NodeRepresentation, int32, error) {
+	closest, rounds := kd.lookup(key)
+	owner := kd.self
+	for _, node := range closest {
+		if node.Id^key < owner.Id^key {
+			owner = node
+		}
+	}
+	return owner, rounds, nil
+}
+
+// IsResponsible holds as long as no known node is closer to key. The
+// lookups reaching this node went through the nodes closest to key, so
+// they would have brought a closer one in.
+func (kd *Kademlia) IsResponsible(key int64) bool {
+	closest := kd.closest(key, 1, "")
+	return len(closest) == 0 || kd.self.Id^key < closest[0].Id^key
+}
+
+// OwnedRange is unknown, the keys owned here being the ones closer to this
+// node than to any other rather than a range of ids.
+func (kd *Kademlia) OwnedRange() (int64, bool) {
+	return 0, false
+}
+
+// ReplicaTargets are the nodes closest to key after this one, the ones that
+// would own it next if this node was gone, one per host.
+func (kd *Kademlia) ReplicaTargets(key int64, count int) []models.NodeRepresentation {
+	hosts := map[string]bool{kd.self.Host(): true}
+	var targets []models.NodeRepresentation
+	for _, node := range kd.closest(key, kd.k, "") {
+		if len(targets) >= count {
+			break
+		}
+		if host := node.Host(); !hosts[host] {
+			hosts[host] = true
+			targets = append(targets, node)
+		}
+	}
+	return targets
+}
+
+func (kd *Kademlia) Closest(key int64, count int, from models.NodeRepresentation) []models.NodeRepresentation {
+	if from.Address != "" {
+		kd.touch(from)
+	}
+	if count < 1 || count > kd.k {
+		count = kd.k
+	}
+	return kd.closest(key, count, from.Address)
+}
+
+func (kd *Kademlia) Members() []models.NodeRepresentation {
+	kd.mu.Lock()
+	defer kd.mu.Unlock()
+	var members []models.NodeRepresentation
+	for _, bucket := range kd.buckets {
+		members = append(members, bucket...)
+	}
+	return members
+}
+
+func (kd *Kademlia) bucket(id int64) int {
+	return bits.Len64(uint64(kd.self.Id^id)) - 1
+}
+
+// touch moves node to the tail of its bucket, the most recently seen end.
+// A full bucket keeps its nodes as long as the least recently seen one
+// still answers, long lived nodes being the likeliest to stay.
+func (kd *Kademlia) touch(node models.NodeRepresentation) {
+	if node.Address == "" || node.Address == kd.self.Address {
+		return
+	}
+	i := kd.bucket(node.Id)
+	if i < 0 || i >= kd.m {
+		return
+	}
+
+	kd.mu.Lock()
+	bucket := kd.buckets[i]
+	for j, known := range bucket {
+		if known.Address == node.Address {
+			kd.buckets[i] = append(append(bucket[:j:j], bucket[j+1:]...), node)
+			kd.mu.Unlock()
+			return
+		}
+	}
+	if len(bucket) < kd.k {
+		kd.buckets[i] = append(bucket, node)
+		kd.mu.Unlock()
+		return
+	}
+	oldest := bucket[0]
+	kd.mu.Unlock()
+
+	go func() {
+		if _, err := kd.client.Ping(oldest.Address); err == nil {
+			kd.touch(oldest)
+			return
+		}
+		log.Println("evicting " + oldest.Address + " from bucket " + strconv.Itoa(i))
+		kd.remove(oldest.Address)
+		kd.touch(node)
+	}()
+}
+
+func (kd *Kademlia) remove(address string) {
+	kd.mu.Lock()
+	defer kd.mu.Unlock()
+	for i, bucket := range kd.buckets {
+		for j, known := range bucket {
+			if known.Address == address {
+				kd.buckets[i] = append(bucket[:j:j], bucket[j+1:]...)
+				return
+			}
+		}
+	}
+}
+
+func (kd *Kademlia) closest(key int64, count int, exclude string) []models.NodeRepresentation {
+	kd.mu.Lock()
+	var nodes []models.NodeRepresentation
+	for _, bucket := range kd.buckets {
+		for _, node := range bucket {
+			if node.Address != exclude {
+				nodes = append(nodes, node)
+			}
+		}
+	}
+	kd.mu.Unlock()
+
+	sortByDistance(nodes, key)
+	if len(nodes) > count {
+		nodes = nodes[:count]
+	}
+	return nodes
+}
+
+func sortByDistance(nodes []models.NodeRepresentation, key int64) {
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].Id^key < nodes[j].Id^key })
+}
+
+type findStep struct {
+	from     models.NodeRepresentation
+	response *grpc_api.FindNodeResponse
+	err      error
+}
+
+// lookup asks the alpha closest unqueried nodes of the shortlist for the
+// nodes they know closest to key, until the k closest ones have all
+// answered. It returns them along with the number of rounds it took.
+func (kd *Kademlia) lookup(key int64) ([]models.NodeRepresentation, int32) {
+	shortlist := kd.closest(key, kd.k, "")
+	seen := map[string]bool{kd.self.Address: true}
+	for _, node := range shortlist {
+		seen[node.Address] = true
+	}
+	queried := make(map[string]bool)
+
+	rounds := int32(0)
+	for int(rounds) < 2*kd.m {
+		var batch []models.NodeRepresentation
+		for _, node := range shortlist {
+			if len(batch) == kd.alpha {
+				break
+			}
+			if !queried[node.Address] {
+				batch = append(batch, node)
+			}
+		}
+		if len(batch) == 0 {
+			break
+		}
+		rounds++
+
+		steps := make(chan findStep, len(batch))
+		for _, node := range batch {
+			queried[node.Address] = true
+			go func(node models.NodeRepresentation) {
+				response, err := kd.client.FindNode(node.Address, key, kd.k, kd.self, kd.timeout)
+				steps <- findStep{from: node, response: response, err: err}
+			}(node)
+		}
+
+		failed := make(map[string]bool)
+		for range batch {
+			step := <-steps
+			if step.err != nil {
+				log.Println("kademlia lookup hop " + step.from.Address + " failed: " + step.err.Error())
+				failed[step.from.Address] = true
+				kd.remove(step.from.Address)
+				continue
+			}
+			kd.touch(step.from)
+			for _, info := range step.response.Nodes {
+				node := nodeOf(info)
+				if !seen[node.Address] {
+					seen[node.Address] = true
+					shortlist = append(shortlist, node)
+				}
+			}
+		}
+
+		alive := shortlist[:0]
+		for _, node := range shortlist {
+			if !failed[node.Address] {
+				alive = append(alive, node)
+			}
+		}
+		shortlist = alive
+		sortByDistance(shortlist, key)
+		if len(shortlist) > kd.k {
+			shortlist = shortlist[:kd.k]
+		}
+	}
+	return shortlist, rounds
+}
+
+func nodeOf(info *grpc_api.NodeInfo) models.NodeRepresentation {
+	return models.NodeRepresentation{Id: info.GetId(), Address: info.GetEndpoint()}
+}
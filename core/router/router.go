@@ -0,0 +1,51 @@
+package router
+
+import (
+	"os"
+
+	"github.com/raonismaneoto/CustomDHT/core/models"
+)
+
+const (
+	ChordOverlay    = "chord"
+	KademliaOverlay = "kademlia"
+)
+
+// Router is the overlay a node takes part in: it joins it, keeps its view of
+// the other nodes up to date, looks keys up and decides which keys the node
+// is responsible for.
+type Router interface {
+	Name() string
+	// Join enters the overlay through seed. On failure the router is left
+	// as if Join was never called, so it can be tried again.
+	Join(seed models.NodeRepresentation) error
+	// Start runs the periodic maintenance of the overlay.
+	Start()
+	Stop()
+	// Leave takes the node out of the overlay, its keys going to the nodes
+	// owning them once it is gone.
+	Leave() error
+	// Owner finds the node responsible for key, along with the number of
+	// remote hops the lookup took.
+	Owner(key int64) (models.NodeRepresentation, int32, error)
+	IsResponsible(key int64) bool
+	// OwnedRange is where the range of keys the node owns starts, the range
+	// ending at the node id, for overlays where it is known.
+	OwnedRange() (int64, bool)
+	// ReplicaTargets are the up to count nodes, each on a host of its own,
+	// the replicas of a key owned here go to.
+	ReplicaTargets(key int64, count int) []models.NodeRepresentation
+	// Closest lists up to count known nodes close to key, as the overlay
+	// measures it, from being asked by from.
+	Closest(key int64, count int, from models.NodeRepresentation) []models.NodeRepresentation
+	// Members lists the nodes the router currently knows.
+	Members() []models.NodeRepresentation
+}
+
+// Overlay is the router nodes use, set by OVERLAY.
+func Overlay() string {
+	if os.Getenv("OVERLAY") == KademliaOverlay {
+		return KademliaOverlay
+	}
+	return ChordOverlay
+}
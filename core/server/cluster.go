@@ -0,0 +1,103 @@
+package Server
+
+import (
+	"context"
+	"log"
+
+	"github.com/raonismaneoto/CustomDHT/commons/grpc_api"
+	"github.com/raonismaneoto/CustomDHT/core/models"
+	"github.com/raonismaneoto/CustomDHT/core/node"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// the calls that change who is in the ring, write replica data or change the
+// key space, which only nodes of the same cluster may make. A replica delete
+// goes through Delete, it is told apart by the request.
+var membershipMethods = map[string]bool{
+	"/grpc_api.DHTNode/HandleNewPredecessor": true,
+	"/grpc_api.DHTNode/HandleNewSuccessor":   true,
+	"/grpc_api.DHTNode/Notify":               true,
+	"/grpc_api.DHTNode/Probe":                true,
+	"/grpc_api.DHTNode/HandleLeave":          true,
+	"/grpc_api.DHTNode/Merge":                true,
+	"/grpc_api.DHTNode/Handoff":              true,
+	"/grpc_api.DHTNode/FindNode":             true,
+	"/grpc_api.DHTNode/RepSave":              true,
+	"/grpc_api.DHTNode/RepPut":               true,
+	"/grpc_api.DHTNode/Migrate":              true,
+}
+
+func checked(method string, req interface{}) bool {
+	if request, ok := req.(*grpc_api.DeleteRequest); ok {
+		return request.Replica
+	}
+	return membershipMethods[method]
+}
+
+// startsMigration tells whether req is the admin call starting a migration,
+// which comes from outside the ring knowing the cluster name alone.
+func startsMigration(req interface{}) bool {
+	request, ok := req.(*grpc_api.MigrateRequest)
+	return ok && request.Origin == "" && !request.Finish
+}
+
+// Interceptors check the cluster identity the caller sent along with every
+// membership call.
+func (s *NodeServer) Interceptors() []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.UnaryInterceptor(func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			if checked(info.FullMethod, req) {
+				if err := s.checkCaller(ctx, startsMigration(req)); err != nil {
+					return nil, err
+				}
+			}
+			return handler(ctx, req)
+		}),
+		grpc.StreamInterceptor(func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			if membershipMethods[info.FullMethod] {
+				if err := s.checkCaller(stream.Context(), false); err != nil {
+					return err
+				}
+			}
+			return handler(srv, stream)
+		}),
+	}
+}
+
+func (s *NodeServer) checkCaller(ctx context.Context, nameOnly bool) error {
+	md, _ := metadata.FromIncomingContext(ctx)
+	var name string
+	if names := md.Get(models.ClusterMetadataKey); len(names) > 0 {
+		name = names[0]
+	}
+	identity, err := models.ParseClusterIdentity(name, md.Get(models.KeySpaceMetadataKey))
+	if err != nil {
+		log.Println("rejecting a call: " + err.Error())
+		return status.Error(codes.PermissionDenied, err.Error())
+	}
+	if nameOnly {
+		if identity.Name != node.ClusterName() {
+			log.Println("rejecting a call from cluster " + identity.Name)
+			return status.Error(codes.PermissionDenied, "cluster mismatch: this node is in cluster "+node.ClusterName())
+		}
+		return nil
+	}
+	return s.node(ctx).CheckIdentity(identity)
+}
+
+func (s *NodeServer) Handshake(ctx context.Context, request *grpc_api.ClusterIdentity) (*grpc_api.ClusterIdentity, error) {
+	log.Println("Handshake call received. Cluster: " + request.Name)
+	n := s.node(ctx)
+	identity, err := models.ParseClusterIdentity(request.Name, request.KeySpaces)
+	if err != nil {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+	if err := n.CheckIdentity(identity); err != nil {
+		return nil, err
+	}
+	mine := n.Identity()
+	return &grpc_api.ClusterIdentity{Name: mine.Name, KeySpaces: mine.KeySpaceStrings()}, nil
+}
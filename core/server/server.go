@@ -6,18 +6,53 @@ import (
 	"io"
 	"log"
 	"strconv"
+	"sync"
 
 	"github.com/raonismaneoto/CustomDHT/commons/grpc_api"
 	"github.com/raonismaneoto/CustomDHT/commons/helpers"
+	"github.com/raonismaneoto/CustomDHT/core/metrics"
+	"github.com/raonismaneoto/CustomDHT/core/models"
 	"github.com/raonismaneoto/CustomDHT/core/node"
+	"github.com/raonismaneoto/CustomDHT/core/storage"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 type NodeServer struct {
-	Node *node.Node
+	Node  *node.Node
+	Nodes []*node.Node
+	byKey map[string]*node.Node
+
+	leaveOnce sync.Once
+	left      chan struct{}
+}
+
+func New(nodes []*node.Node) *NodeServer {
+	s := &NodeServer{Node: nodes[0], Nodes: nodes, byKey: make(map[string]*node.Node), left: make(chan struct{})}
+	for token, n := range nodes {
+		s.byKey[strconv.Itoa(token)] = n
+	}
+	return s
 }
 
-func New(nodeId int64, address string, m int) *NodeServer {
-	return &NodeServer{Node: node.New(nodeId, address, m)}
+// node resolves the virtual node a request is addressed to. Requests without
+// a token go to the first virtual node.
+func (s *NodeServer) node(ctx context.Context) *node.Node {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return s.Node
+	}
+	tokens := md.Get(models.VirtualNodeMetadataKey)
+	if len(tokens) == 0 {
+		return s.Node
+	}
+	n, ok := s.byKey[tokens[0]]
+	if !ok {
+		log.Println("unknown virtual node token: " + tokens[0])
+		return s.Node
+	}
+	return n
 }
 
 func (*NodeServer) Ping(ctx context.Context, request *grpc_api.Empty) (*grpc_api.Empty, error) {
@@ -27,13 +62,13 @@ func (*NodeServer) Ping(ctx context.Context, request *grpc_api.Empty) (*grpc_api
 
 func (s *NodeServer) Successor(ctx context.Context, request *grpc_api.Empty) (*grpc_api.SuccessorResponse, error) {
 	log.Println("Successor call received")
-	response, err := s.Node.Successor()
+	response, err := s.node(ctx).Successor()
 
 	if err != nil {
 		return &grpc_api.SuccessorResponse{
 			Id:       0,
 			Endpoint: "",
-		}, err
+		}, status.Error(codes.NotFound, err.Error())
 	}
 
 	return &grpc_api.SuccessorResponse{
@@ -42,15 +77,24 @@ func (s *NodeServer) Successor(ctx context.Context, request *grpc_api.Empty) (*g
 	}, nil
 }
 
+func (s *NodeServer) SuccessorList(ctx context.Context, request *grpc_api.Empty) (*grpc_api.SuccessorListResponse, error) {
+	log.Println("SuccessorList call received")
+	response := &grpc_api.SuccessorListResponse{}
+	for _, succ := range s.node(ctx).SuccessorList() {
+		response.Successors = append(response.Successors, &grpc_api.NodeInfo{Id: succ.Id, Endpoint: succ.Address})
+	}
+	return response, nil
+}
+
 func (s *NodeServer) Predecessor(ctx context.Context, request *grpc_api.Empty) (*grpc_api.PredecessorResponse, error) {
 	log.Println("Predecessor call received")
-	response, err := s.Node.Predecessor()
+	response, err := s.node(ctx).Predecessor()
 
 	if err != nil {
 		return &grpc_api.PredecessorResponse{
 			Id:       0,
 			Endpoint: "",
-		}, err
+		}, status.Error(codes.NotFound, err.Error())
 	}
 
 	return &grpc_api.PredecessorResponse{
@@ -59,10 +103,84 @@ func (s *NodeServer) Predecessor(ctx context.Context, request *grpc_api.Empty) (
 	}, nil
 }
 
+func (s *NodeServer) Notify(ctx context.Context, request *grpc_api.NotifyRequest) (*grpc_api.NotifyResponse, error) {
+	log.Println("Notify call received. Candidate predecessor id: " + strconv.FormatInt(request.Id, 10))
+	accepted := s.node(ctx).Notify(models.NodeRepresentation{Id: request.Id, Address: request.Endpoint})
+	return &grpc_api.NotifyResponse{Accepted: accepted}, nil
+}
+
+func (s *NodeServer) Probe(ctx context.Context, request *grpc_api.ProbeRequest) (*grpc_api.ProbeResponse, error) {
+	return s.node(ctx).Probe(request), nil
+}
+
+// LeaveAll takes every virtual node of the process out of the ring, one at
+// a time, and closes Left once they are all gone.
+func (s *NodeServer) LeaveAll() {
+	s.leaveOnce.Do(func() {
+		for _, n := range s.Nodes {
+			if err := n.Leave(); err != nil {
+				log.Println("virtual node " + n.Address() + " did not leave cleanly: " + err.Error())
+			}
+		}
+		close(s.left)
+	})
+}
+
+func (s *NodeServer) Left() <-chan struct{} {
+	return s.left
+}
+
+func (s *NodeServer) Leave(ctx context.Context, request *grpc_api.Empty) (*grpc_api.Empty, error) {
+	log.Println("Leave call received")
+	s.LeaveAll()
+	return &grpc_api.Empty{}, nil
+}
+
+func (s *NodeServer) HandleLeave(ctx context.Context, request *grpc_api.LeaveNotice) (*grpc_api.Empty, error) {
+	log.Println("HandleLeave call received. Leaving node: " + request.GetLeaving().GetEndpoint())
+	s.node(ctx).HandleLeave(nodeOf(request.GetLeaving()), nodeOf(request.GetPredecessor()), nodeOf(request.GetSuccessor()))
+	return &grpc_api.Empty{}, nil
+}
+
+func nodeOf(info *grpc_api.NodeInfo) models.NodeRepresentation {
+	return models.NodeRepresentation{Id: info.GetId(), Address: info.GetEndpoint()}
+}
+
+func (s *NodeServer) Merge(ctx context.Context, request *grpc_api.MergeRequest) (*grpc_api.Empty, error) {
+	log.Println("Merge call received. Candidate: " + request.Candidate.GetEndpoint())
+	go s.node(ctx).Merge(nodeOf(request.Candidate), request.Hops)
+	return &grpc_api.Empty{}, nil
+}
+
+func (s *NodeServer) Handoff(srv grpc_api.DHTNode_HandoffServer) error {
+	log.Println("handoff stream received")
+	ctx := srv.Context()
+	n := s.node(ctx)
+
+	count := 0
+	for {
+		entry, err := srv.Recv()
+		if err == io.EOF {
+			log.Println("took " + strconv.Itoa(count) + " handed off keys")
+			return srv.SendAndClose(&grpc_api.Empty{})
+		}
+		if err != nil {
+			return err
+		}
+
+		err = n.TakeHandoff(storage.Entry{Key: entry.Key, Data: entry.Data, Name: entry.Name, Version: entry.Version}, entry.Replica)
+		if err != nil {
+			log.Printf("unable to take handed off key %v: %v", entry.Key, err)
+			return err
+		}
+		count++
+	}
+}
+
 func (s *NodeServer) HandleNewPredecessor(ctx context.Context, request *grpc_api.HandleNewPredecessorRequest) (*grpc_api.HandleNewPredecessorResponse, error) {
 	log.Println("HandleNewPredecessor call received. New predecessor id: " + strconv.FormatInt(request.Id, 10))
 
-	err := s.Node.HandleNewPredecessor(struct {
+	err := s.node(ctx).HandleNewPredecessor(struct {
 		Id      int64
 		Address string
 	}{Id: request.Id, Address: request.Endpoint})
@@ -81,7 +199,7 @@ func (s *NodeServer) HandleNewPredecessor(ctx context.Context, request *grpc_api
 func (s *NodeServer) HandleNewSuccessor(ctx context.Context, request *grpc_api.HandleNewSuccessorRequest) (*grpc_api.HandleNewSuccessorResponse, error) {
 	log.Println("HandleNewSuccessor call received. New successor id: " + strconv.FormatInt(request.Id, 10))
 
-	err := s.Node.HandleNewSuccessor(struct {
+	err := s.node(ctx).HandleNewSuccessor(struct {
 		Id      int64
 		Address string
 	}{Id: request.Id, Address: request.Endpoint}, struct {
@@ -105,39 +223,99 @@ func (s *NodeServer) Query(ctx context.Context, request *grpc_api.QueryRequest)
 		if request.StrKey == "" {
 			return nil, errors.New("invalid request, no key found")
 		}
-		request.Key = helpers.GetHash(request.StrKey, s.Node.M)
+		if helpers.IsOrderedKey(request.StrKey) {
+			log.Println("Query call received. Ordered key: " + request.StrKey)
+			if err := orderedConsistency(request.Consistency); err != nil {
+				return nil, err
+			}
+			response := s.node(ctx).QueryOrdered(request.StrKey)
+			if len(response.Data) == 0 {
+				if previous := s.node(ctx).QueryPreviousKeySpace(request.StrKey); previous != nil {
+					return s.queryResponse(request.StrKey, previous)
+				}
+			}
+			return s.queryResponse(request.StrKey, &response)
+		}
+		request.Key = s.node(ctx).KeyPosition(request.StrKey)
+	}
+	// a sender routing from a cached owner is told when it has it wrong
+	if request.Direct && !s.node(ctx).Owns(request.Key) {
+		return nil, node.ErrNotOwner
+	}
+	if request.Local {
+		log.Println("Local query call received. Key: " + strconv.FormatInt(request.Key, 10))
+		response := s.node(ctx).QueryLocal(request.Key, request.StrKey)
+		return &response, nil
 	}
 	log.Println("Query call received. Key: " + strconv.FormatInt(request.Key, 10))
-	response := s.Node.Query(request.Key)
+	var (
+		response *grpc_api.QueryResponse
+		err      error
+	)
+	// a request that already went through other nodes stays recursive
+	if request.Hops == 0 && node.IsIterative(request.Iterative) {
+		response, err = s.node(ctx).IterativeQuery(request.Key, request.Consistency)
+		if err != nil {
+			log.Println(err.Error())
+			return nil, err
+		}
+		if !request.Trace {
+			response.Path = nil
+		}
+	} else {
+		route := routeOf(request.Trace, request.Hops, request.Visited)
+		route.Consistency = request.Consistency
+		response, err = s.node(ctx).RoutedQuery(request.Key, route)
+		if err != nil && !request.Trace {
+			log.Println(err.Error())
+			return nil, err
+		}
+	}
+	if request.Trace && response.ResponsibleNodeId == 0 {
+		// keeping the path of a lookup that broke somewhere is the point of
+		// tracing, so it is not turned into an error
+		return response, nil
+	}
+	if len(response.Data) == 0 {
+		if previous := s.node(ctx).QueryPreviousKeySpace(request.StrKey); previous != nil {
+			return s.queryResponse(strconv.FormatInt(request.Key, 10), previous)
+		}
+	}
+	return s.queryResponse(strconv.FormatInt(request.Key, 10), response)
+}
 
+func (s *NodeServer) queryResponse(key string, response *grpc_api.QueryResponse) (*grpc_api.QueryResponse, error) {
 	if response.ResponsibleNodeId == 0 {
-		log.Println("Key: " + strconv.FormatInt(request.Key, 10) + " not found.")
-		return &response, errors.New("Key not found")
+		log.Println("Key: " + key + " not found.")
+		return response, errors.New("Key not found")
 	}
 
-	return &response, nil
+	return response, nil
 }
 
 func (s *NodeServer) QueryStream(request *grpc_api.QueryRequest, srv grpc_api.DHTNode_QueryStreamServer) error {
+	ctx := srv.Context()
 	if request.Key == 0 {
 		if request.StrKey == "" {
 			return errors.New("invalid request, no key found")
 		}
-		request.Key = helpers.GetHash(request.StrKey, s.Node.M)
+		request.Key = s.node(ctx).KeyPosition(request.StrKey)
 	}
 	log.Println("Query call received. Key: " + strconv.FormatInt(request.Key, 10))
-	ctx := srv.Context()
 
-	cbuffer := make(chan grpc_api.QueryResponse)
-	go s.Node.QueryAsync(request.Key, cbuffer)
+	cbuffer := make(chan *grpc_api.QueryResponse)
+	ebuffer := make(chan error, 1)
+	go s.node(ctx).QueryAsync(request.Key, cbuffer, ebuffer)
 	for {
+		var response *grpc_api.QueryResponse
+		var ok bool
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
-		default:
+		case err := <-ebuffer:
+			return err
+		case response, ok = <-cbuffer:
 		}
-
-		response, ok := <-cbuffer
 		if !ok {
 			return nil
 		}
@@ -146,7 +324,7 @@ func (s *NodeServer) QueryStream(request *grpc_api.QueryRequest, srv grpc_api.DH
 			return errors.New("key not found")
 		}
 
-		if err := srv.Send(&response); err != nil {
+		if err := srv.Send(response); err != nil {
 			log.Printf("send error %v", err)
 			return err
 		}
@@ -158,10 +336,21 @@ func (s *NodeServer) Save(ctx context.Context, request *grpc_api.SaveRequest) (*
 		if request.StrKey == "" {
 			return nil, errors.New("invalid request, no key found")
 		}
-		request.Key = helpers.GetHash(request.StrKey, s.Node.M)
+		if helpers.IsOrderedKey(request.StrKey) {
+			log.Println("Save call received. Ordered key: " + request.StrKey)
+			if err := orderedConsistency(request.Consistency); err != nil {
+				return nil, err
+			}
+			err := s.node(ctx).SaveOrdered(request.StrKey, request.Data)
+			return &grpc_api.Empty{}, err
+		}
+		request.Key = s.node(ctx).KeyPosition(request.StrKey)
+	}
+	if request.Direct && !s.node(ctx).Owns(request.Key) {
+		return nil, node.ErrNotOwner
 	}
 	log.Println("Save call received. Key: " + strconv.FormatInt(request.Key, 10))
-	err := s.Node.Save(request.Key, request.Data)
+	err := s.node(ctx).Save(request.Key, request.StrKey, request.Data, request.Consistency)
 	return &grpc_api.Empty{}, err
 }
 
@@ -193,10 +382,10 @@ func (s *NodeServer) SaveStream(srv grpc_api.DHTNode_SaveStreamServer) error {
 			if req.StrKey == "" {
 				return errors.New("invalid request, no key found")
 			}
-			req.Key = helpers.GetHash(req.StrKey, s.Node.M)
+			req.Key = s.node(ctx).KeyPosition(req.StrKey)
 		}
 
-		err = s.Node.Save(req.Key, req.Data)
+		err = s.node(ctx).Save(req.Key, req.StrKey, req.Data, req.Consistency)
 		if err != nil {
 			log.Printf("received error %v", err)
 			return err
@@ -210,11 +399,35 @@ func (s *NodeServer) Delete(ctx context.Context, request *grpc_api.DeleteRequest
 		if request.StrKey == "" {
 			return nil, errors.New("invalid request, no key found")
 		}
-		request.Key = helpers.GetHash(request.StrKey, s.Node.M)
+		if helpers.IsOrderedKey(request.StrKey) {
+			log.Println("Delete call received. Ordered key: " + request.StrKey)
+			if err := orderedConsistency(request.Consistency); err != nil {
+				return nil, err
+			}
+			err := s.node(ctx).DeleteOrdered(request.StrKey)
+			return &grpc_api.Empty{}, err
+		}
+		request.Key = s.node(ctx).KeyPosition(request.StrKey)
+	}
+	if request.Replica {
+		log.Println("Replica delete call received. Key: " + strconv.FormatInt(request.Key, 10))
+		return &grpc_api.Empty{}, s.node(ctx).DeleteReplica(request.Key, request.Version)
 	}
 	log.Println("Delete call received. Key: " + strconv.FormatInt(request.Key, 10))
-	s.Node.Delete(request.Key)
-	return &grpc_api.Empty{}, nil
+	return &grpc_api.Empty{}, s.node(ctx).Delete(request.Key, request.Consistency)
+}
+
+// orderedConsistency turns down the levels above ONE for ordered keys,
+// whose buckets are copied to the replicas as a whole.
+func orderedConsistency(level string) error {
+	acks, err := node.Acks(level)
+	if err != nil {
+		return err
+	}
+	if acks > 1 {
+		return status.Error(codes.InvalidArgument, "ordered keys are only read and written at consistency ONE")
+	}
+	return nil
 }
 
 func (s *NodeServer) RepSave(ctx context.Context, request *grpc_api.RepSaveRequest) (*grpc_api.Empty, error) {
@@ -222,10 +435,13 @@ func (s *NodeServer) RepSave(ctx context.Context, request *grpc_api.RepSaveReque
 		if request.StrKey == "" {
 			return nil, errors.New("invalid request, no key found")
 		}
-		request.Key = helpers.GetHash(request.StrKey, s.Node.M)
+		request.Key = s.node(ctx).KeyPosition(request.StrKey)
 	}
 	log.Println("RepSave call received. Key: " + strconv.FormatInt(request.Key, 10))
-	s.Node.RepSave(request.Key, request.Value)
+	if request.Sync {
+		return &grpc_api.Empty{}, s.node(ctx).StoreReplica(request.Key, request.StrKey, request.Value, request.Replace, request.Version)
+	}
+	s.node(ctx).RepSave(request.Key, request.StrKey, request.Value, request.Replace, request.Version)
 	return &grpc_api.Empty{}, nil
 }
 
@@ -234,12 +450,140 @@ func (s *NodeServer) Owner(ctx context.Context, request *grpc_api.OwnerRequest)
 		if request.StrKey == "" {
 			return nil, errors.New("invalid request, no key found")
 		}
-		request.Key = helpers.GetHash(request.StrKey, s.Node.M)
+		request.Key = s.node(ctx).KeyPosition(request.StrKey)
 	}
 	log.Println("Owner call received. Key: " + strconv.FormatInt(request.Key, 10))
-	resp, err := s.Node.Owner(request.Key)
+	if request.Hops == 0 && node.IsIterative(request.Iterative) {
+		owner, path, err := s.node(ctx).IterativeOwner(request.Key)
+		if err != nil {
+			log.Println(err.Error())
+			return nil, err
+		}
+		resp := &grpc_api.OwnerResponse{OwnerNodeId: owner.Id, OwnerNodeEndpoint: owner.Address}
+		if request.Trace {
+			resp.Path = path
+		}
+		return resp, nil
+	}
+	resp, err := s.node(ctx).RoutedOwner(request.Key, routeOf(request.Trace, request.Hops, request.Visited))
 	if err != nil {
+		log.Println(err.Error())
 		return nil, err
 	}
 	return resp, nil
 }
+
+func routeOf(trace bool, hops int32, visited []string) node.Route {
+	return node.Route{Trace: trace, Hops: hops, Visited: visited}
+}
+
+func (s *NodeServer) ClosestPreceding(ctx context.Context, request *grpc_api.ClosestPrecedingRequest) (*grpc_api.ClosestPrecedingResponse, error) {
+	log.Println("ClosestPreceding call received. Key: " + strconv.FormatInt(request.Key, 10))
+	owner, candidates, done := s.node(ctx).ClosestPreceding(request.Key, int(request.Count))
+	response := &grpc_api.ClosestPrecedingResponse{Done: done}
+	if done {
+		response.Owner = &grpc_api.NodeInfo{Id: owner.Id, Endpoint: owner.Address}
+	}
+	for _, candidate := range candidates {
+		response.Candidates = append(response.Candidates, &grpc_api.NodeInfo{Id: candidate.Id, Endpoint: candidate.Address})
+	}
+	return response, nil
+}
+
+func (s *NodeServer) FindNode(ctx context.Context, request *grpc_api.FindNodeRequest) (*grpc_api.FindNodeResponse, error) {
+	n := s.node(ctx)
+	response := &grpc_api.FindNodeResponse{Responder: &grpc_api.NodeInfo{Id: n.Id(), Endpoint: n.Address()}}
+	for _, node := range n.FindNode(request.Key, int(request.Count), nodeOf(request.Sender)) {
+		response.Nodes = append(response.Nodes, &grpc_api.NodeInfo{Id: node.Id, Endpoint: node.Address})
+	}
+	return response, nil
+}
+
+func (s *NodeServer) Fingers(ctx context.Context, request *grpc_api.Empty) (*grpc_api.FingersResponse, error) {
+	response := &grpc_api.FingersResponse{}
+	for _, finger := range s.node(ctx).Fingers() {
+		response.Fingers = append(response.Fingers, &grpc_api.NodeInfo{Id: finger.Id, Endpoint: finger.Address})
+	}
+	return response, nil
+}
+
+func (s *NodeServer) CheckRing(ctx context.Context, request *grpc_api.Empty) (*grpc_api.CheckRingResponse, error) {
+	log.Println("CheckRing call received")
+	return s.node(ctx).CheckRing(), nil
+}
+
+func (s *NodeServer) RangeQuery(ctx context.Context, request *grpc_api.RangeQueryRequest) (*grpc_api.RangeQueryResponse, error) {
+	log.Println("RangeQuery call received. Namespace: " + request.Namespace + ", start: " + request.Start + ", end: " + request.End)
+	n := s.node(ctx)
+	if !request.Local {
+		items, err := n.RangeQuery(request.Namespace, request.Start, request.End, int(request.Limit))
+		if err != nil {
+			return nil, err
+		}
+		return &grpc_api.RangeQueryResponse{Items: items, NodeId: n.Id(), NodeEndpoint: n.Address()}, nil
+	}
+
+	response := &grpc_api.RangeQueryResponse{
+		Items:        n.LocalRange(request.Namespace, request.Start, request.End, int(request.Limit)),
+		NodeId:       n.Id(),
+		NodeEndpoint: n.Address(),
+	}
+	if succ, err := n.Successor(); err == nil {
+		response.SuccessorId = succ.Id
+		response.SuccessorEndpoint = succ.Address
+	}
+	return response, nil
+}
+
+func (s *NodeServer) Load(ctx context.Context, request *grpc_api.Empty) (*grpc_api.LoadResponse, error) {
+	log.Println("Load call received")
+	keyCount, splitKey := s.node(ctx).Load()
+	return &grpc_api.LoadResponse{KeyCount: keyCount, SplitKey: splitKey}, nil
+}
+
+func (s *NodeServer) Keys(ctx context.Context, request *grpc_api.KeysRequest) (*grpc_api.KeysResponse, error) {
+	log.Println("Keys call received")
+	n := s.node(ctx)
+	response := &grpc_api.KeysResponse{Keys: n.Keys(request.Start, request.End)}
+	for _, key := range response.Keys {
+		response.Versions = append(response.Versions, n.Version(key))
+	}
+	return response, nil
+}
+
+func (s *NodeServer) Migrate(ctx context.Context, request *grpc_api.MigrateRequest) (*grpc_api.Empty, error) {
+	log.Println("Migrate call received. M: " + strconv.Itoa(int(request.M)) + ", hash: " + request.HashAlgorithm)
+	target := models.KeySpace{M: int(request.M), Hash: request.HashAlgorithm}
+	return &grpc_api.Empty{}, s.node(ctx).Migrate(target, request.Origin, request.Finish)
+}
+
+func (s *NodeServer) Stats(ctx context.Context, request *grpc_api.Empty) (*grpc_api.StatsResponse, error) {
+	log.Println("Stats call received")
+	return &grpc_api.StatsResponse{Counters: metrics.Snapshot()}, nil
+}
+
+func (s *NodeServer) Health(ctx context.Context, request *grpc_api.Empty) (*grpc_api.HealthResponse, error) {
+	n := s.node(ctx)
+	health := n.Health()
+	return &grpc_api.HealthResponse{
+		Id:           n.Id(),
+		Endpoint:     n.Address(),
+		Status:       health.Status,
+		JoinAttempts: int32(health.Attempts),
+		Seed:         health.Seed,
+		LastError:    health.LastError,
+	}, nil
+}
+
+func (s *NodeServer) GetNodeState(ctx context.Context, request *grpc_api.Empty) (*grpc_api.NodeStateResponse, error) {
+	log.Println("GetNodeState call received")
+	return s.node(ctx).State(), nil
+}
+
+func (s *NodeServer) MigrationStatus(ctx context.Context, request *grpc_api.MigrationStatusRequest) (*grpc_api.MigrationStatusResponse, error) {
+	log.Println("MigrationStatus call received")
+	if request.Cluster {
+		return s.node(ctx).ClusterMigrationStatus()
+	}
+	return s.node(ctx).MigrationStatus(), nil
+}
@@ -0,0 +1,119 @@
+package storage
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+)
+
+// journal keeps a map of keys on disk as a snapshot, path, and a log of the
+// changes made since, path.log, so a change costs an append instead of a
+// rewrite of the whole map. The snapshot is written again once the log
+// outgrows the map.
+type journal struct {
+	path  string
+	log   *os.File
+	lines int
+}
+
+type journalLine struct {
+	Key     int64
+	Value   json.RawMessage `json:",omitempty"`
+	Deleted bool            `json:",omitempty"`
+}
+
+// openJournal loads the snapshot at path into snapshot and hands the changes
+// logged since over to apply, value being nil for a deleted key.
+func openJournal(path string, snapshot interface{}, apply func(key int64, value json.RawMessage)) *journal {
+	j := &journal{path: path}
+	if content, err := ioutil.ReadFile(path); err == nil {
+		if err := json.Unmarshal(content, snapshot); err != nil {
+			log.Println("unable to load " + path + ": " + err.Error())
+		}
+	}
+
+	if file, err := os.Open(path + ".log"); err == nil {
+		reader := bufio.NewReader(file)
+		for {
+			content, err := reader.ReadBytes('\n')
+			var line journalLine
+			// a line cut short by a crash is the last one, and is skipped
+			if len(content) > 0 && json.Unmarshal(content, &line) == nil {
+				if line.Deleted {
+					line.Value = nil
+				}
+				apply(line.Key, line.Value)
+				j.lines++
+			}
+			if err != nil {
+				if err != io.EOF {
+					log.Println("unable to read " + path + ".log: " + err.Error())
+				}
+				break
+			}
+		}
+		file.Close()
+	}
+
+	var err error
+	j.log, err = os.OpenFile(path+".log", os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		log.Println("unable to open " + path + ".log: " + err.Error())
+	}
+	return j
+}
+
+// record logs the change of key to value, or its deletion when value is nil.
+// snapshot is the whole map, of size entries, written when the log is
+// compacted.
+func (j *journal) record(key int64, value interface{}, snapshot interface{}, size int) {
+	if j.log == nil {
+		return
+	}
+	line := journalLine{Key: key, Deleted: value == nil}
+	if value != nil {
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			log.Println("unable to encode the change of " + j.path + ": " + err.Error())
+			return
+		}
+		line.Value = encoded
+	}
+	content, err := json.Marshal(line)
+	if err != nil {
+		log.Println("unable to encode the change of " + j.path + ": " + err.Error())
+		return
+	}
+	if _, err := j.log.Write(append(content, '\n')); err != nil {
+		log.Println("unable to log the change of " + j.path + ": " + err.Error())
+		return
+	}
+	j.lines++
+	if j.lines > size+1024 {
+		j.compact(snapshot)
+	}
+}
+
+func (j *journal) compact(snapshot interface{}) {
+	content, err := json.Marshal(snapshot)
+	if err != nil {
+		log.Println("unable to encode " + j.path + ": " + err.Error())
+		return
+	}
+	if err := ioutil.WriteFile(j.path+".tmp", content, 0644); err != nil {
+		log.Println("unable to persist " + j.path + ": " + err.Error())
+		return
+	}
+	if err := os.Rename(j.path+".tmp", j.path); err != nil {
+		log.Println("unable to persist " + j.path + ": " + err.Error())
+		return
+	}
+	if err := j.log.Truncate(0); err != nil {
+		log.Println("unable to truncate " + j.path + ".log: " + err.Error())
+		return
+	}
+	j.lines = 0
+}
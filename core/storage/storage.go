@@ -1,49 +1,96 @@
 package storage
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"log"
 	"math"
 	"os"
+	"strconv"
+	"sync"
+	"time"
 
 	"github.com/raonismaneoto/CustomDHT/commons/helpers"
 	"github.com/raonismaneoto/CustomDHT/core/models"
 )
 
+const (
+	namesFile      = "names.json"
+	versionsFile   = "versions.json"
+	tombstonesFile = "tombstones.json"
+)
+
 type Storage struct {
 	Type       models.MemType
+	mu         sync.RWMutex
+	bucketMu   sync.Mutex
+	writeMu    sync.Mutex
+	namesMu    sync.RWMutex
+	versionsMu sync.Mutex
 	memStorage map[int64][]byte
-	root       string
-	chunkLimit int64
+	names      map[int64]string
+	versions   map[int64]int64
+	// tombstones are the versions of the deletes, kept so older copies of
+	// the deleted values are not taken back
+	tombstones    map[int64]int64
+	namesLog      *journal
+	versionsLog   *journal
+	tombstonesLog *journal
+	dir           string
+	root          string
+	chunkLimit    int64
 }
 
+// Entry is a stored value. Name is the original key, when known, so the
+// entry can be placed again if the key space changes. Version is the time
+// of the write, in unix nanoseconds, the latest write winning when copies
+// of a key diverged; entries saved without one are new writes.
 type Entry struct {
-	Key  int64
-	Data []byte
+	Key     int64
+	Data    []byte
+	Name    string
+	Version int64
 }
 
-func New(t models.MemType) Storage {
+func New(t models.MemType) *Storage {
+	return NewIn(t, helpers.DataDir())
+}
+
+// NewIn is New keeping the files in dir instead of DATA_DIR.
+func NewIn(t models.MemType, dir string) *Storage {
 	if t == models.NotSupported {
 		panic("invalid MemType for the storage")
 	}
 
-	s := Storage{}
+	s := &Storage{}
 	s.memStorage = make(map[int64][]byte)
 	s.Type = t
+	s.dir = dir
 	if s.Type == models.Disk {
-		os.Mkdir("./data", 0644)
-		s.root = "./data"
+		s.root = dir + "/data"
+		os.MkdirAll(s.root, 0755)
 	} else {
-		os.Mkdir("./flushed-data", 0644)
-		s.root = "./flushed-data"
+		s.root = dir + "/flushed-data"
+		os.MkdirAll(s.root, 0755)
 		helpers.PeriodicInvocation(s.FlushMem, 3600)
 	}
 	s.chunkLimit = 10000
+	s.loadNames()
+	s.loadVersions()
 	return s
 }
 
+// Dir is the directory the storage keeps its files in, for the node to keep
+// its own next to them.
+func (s *Storage) Dir() string {
+	return s.dir
+}
+
 func (s *Storage) Save(data Entry) error {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
 	var err error
 	if s.Type == models.Mem {
 		err = s.saveMem(data)
@@ -52,10 +99,12 @@ func (s *Storage) Save(data Entry) error {
 	}
 
 	if err != nil {
-		log.Println("error while saving data: %v", err)
+		log.Printf("error while saving data: %v", err)
 		return err
 	}
 
+	s.setName(data.Key, data.Name)
+	s.setVersion(data.Key, data.Version)
 	return nil
 }
 
@@ -69,7 +118,7 @@ func (s *Storage) Read(key int64) ([]byte, error) {
 	}
 
 	if err != nil {
-		log.Println("error while reading data: %v", err)
+		log.Printf("error while reading data: %v", err)
 		return nil, err
 	}
 
@@ -77,6 +126,12 @@ func (s *Storage) Read(key int64) ([]byte, error) {
 }
 
 func (s *Storage) Delete(key int64) error {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	return s.delete(key)
+}
+
+func (s *Storage) delete(key int64) error {
 	var err error
 	if s.Type == models.Mem {
 		err = s.deleteMem(key)
@@ -85,17 +140,325 @@ func (s *Storage) Delete(key int64) error {
 	}
 
 	if err != nil {
-		log.Println("error while deleting data: %v", err)
+		log.Printf("error while deleting data: %v", err)
+		return err
+	}
+
+	s.forgetName(key)
+	s.forgetVersion(key)
+	return nil
+}
+
+func (s *Storage) Name(key int64) (string, bool) {
+	s.namesMu.RLock()
+	defer s.namesMu.RUnlock()
+	name, ok := s.names[key]
+	return name, ok
+}
+
+func (s *Storage) setName(key int64, name string) {
+	if name == "" {
+		return
+	}
+	s.namesMu.Lock()
+	defer s.namesMu.Unlock()
+	if s.names[key] == name {
+		return
+	}
+	s.names[key] = name
+	s.namesLog.record(key, name, s.names, len(s.names))
+}
+
+func (s *Storage) forgetName(key int64) {
+	s.namesMu.Lock()
+	defer s.namesMu.Unlock()
+	if _, ok := s.names[key]; !ok {
+		return
+	}
+	delete(s.names, key)
+	s.namesLog.record(key, nil, s.names, len(s.names))
+}
+
+func (s *Storage) loadNames() {
+	s.names = make(map[int64]string)
+	s.namesLog = openJournal(s.root+"/"+namesFile, &s.names, func(key int64, value json.RawMessage) {
+		var name string
+		if value == nil || json.Unmarshal(value, &name) != nil {
+			delete(s.names, key)
+			return
+		}
+		s.names[key] = name
+	})
+}
+
+// Version is the version of the value stored under key, 0 when it has none.
+func (s *Storage) Version(key int64) int64 {
+	s.versionsMu.Lock()
+	defer s.versionsMu.Unlock()
+	return s.versions[key]
+}
+
+// PutIfNewer puts the entry unless the value stored under its key, or its
+// delete, is as recent, telling whether it did. No other write gets in
+// between the check and the put.
+func (s *Storage) PutIfNewer(data Entry) (bool, error) {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	if data.Version != 0 && s.Tombstone(data.Key) >= data.Version {
+		return false, nil
+	}
+	if _, err := s.Read(data.Key); err == nil && data.Version != 0 && s.Version(data.Key) >= data.Version {
+		return false, nil
+	}
+	return true, s.put(data)
+}
+
+// Remove deletes the value stored under key as of version, now when it is
+// 0, leaving a tombstone behind. A more recent value stays, Remove telling
+// whether it did not.
+func (s *Storage) Remove(key int64, version int64) (bool, error) {
+	if version == 0 {
+		version = time.Now().UnixNano()
+	}
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	if s.Version(key) > version {
+		return false, nil
+	}
+	if _, err := s.Read(key); err == nil {
+		if err := s.delete(key); err != nil && !os.IsNotExist(err) {
+			return false, err
+		}
+	}
+
+	s.versionsMu.Lock()
+	defer s.versionsMu.Unlock()
+	if s.tombstones[key] < version {
+		s.tombstones[key] = version
+		if s.tombstonesLog != nil {
+			s.tombstonesLog.record(key, version, s.tombstones, len(s.tombstones))
+		}
+	}
+	return true, nil
+}
+
+// Tombstone is the version of the delete of key, 0 when it was not deleted
+// or a later write took its place.
+func (s *Storage) Tombstone(key int64) int64 {
+	s.versionsMu.Lock()
+	defer s.versionsMu.Unlock()
+	return s.tombstones[key]
+}
+
+// ExpireTombstones forgets the deletes older than before, by when every
+// copy is expected to have seen them.
+func (s *Storage) ExpireTombstones(before int64) {
+	s.versionsMu.Lock()
+	defer s.versionsMu.Unlock()
+	for key, version := range s.tombstones {
+		if version < before {
+			s.forgetTombstone(key)
+		}
+	}
+}
+
+// forgetTombstone expects the versions to be locked.
+func (s *Storage) forgetTombstone(key int64) {
+	delete(s.tombstones, key)
+	if s.tombstonesLog != nil {
+		s.tombstonesLog.record(key, nil, s.tombstones, len(s.tombstones))
+	}
+}
+
+func (s *Storage) setVersion(key int64, version int64) {
+	if version == 0 {
+		version = time.Now().UnixNano()
+	}
+	s.versionsMu.Lock()
+	defer s.versionsMu.Unlock()
+	s.versions[key] = version
+	s.persistVersion(key, version)
+	if tombstone, ok := s.tombstones[key]; ok && tombstone < version {
+		s.forgetTombstone(key)
+	}
+}
+
+func (s *Storage) forgetVersion(key int64) {
+	s.versionsMu.Lock()
+	defer s.versionsMu.Unlock()
+	delete(s.versions, key)
+	s.persistVersion(key, 0)
+}
+
+// loadVersions takes back the versions kept along with the data on disk,
+// memory storage losing both on a restart.
+func (s *Storage) loadVersions() {
+	s.versions = make(map[int64]int64)
+	s.tombstones = make(map[int64]int64)
+	if s.Type != models.Disk {
+		return
+	}
+	s.tombstonesLog = openJournal(s.root+"/"+tombstonesFile, &s.tombstones, func(key int64, value json.RawMessage) {
+		var version int64
+		if value == nil || json.Unmarshal(value, &version) != nil {
+			delete(s.tombstones, key)
+			return
+		}
+		s.tombstones[key] = version
+	})
+	s.versionsLog = openJournal(s.root+"/"+versionsFile, &s.versions, func(key int64, value json.RawMessage) {
+		var version int64
+		if value == nil || json.Unmarshal(value, &version) != nil {
+			delete(s.versions, key)
+			return
+		}
+		s.versions[key] = version
+	})
+}
+
+// persistVersion logs the change of the version of key, 0 forgetting it. It
+// expects the versions to be locked.
+func (s *Storage) persistVersion(key int64, version int64) {
+	if s.versionsLog == nil {
+		return
+	}
+	if version == 0 {
+		s.versionsLog.record(key, nil, s.versions, len(s.versions))
+		return
+	}
+	s.versionsLog.record(key, version, s.versions, len(s.versions))
+}
+
+// Put replaces whatever is stored under the entry key, while Save appends to
+// it on disk.
+func (s *Storage) Put(data Entry) error {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	return s.put(data)
+}
+
+func (s *Storage) put(data Entry) error {
+	var err error
+	if s.Type == models.Mem {
+		err = s.saveMem(data)
+	} else {
+		err = s.putDisk(data)
+	}
+
+	if err != nil {
+		log.Printf("error while putting data: %v", err)
 		return err
 	}
 
+	s.setName(data.Key, data.Name)
+	s.setVersion(data.Key, data.Version)
 	return nil
 }
 
+func (s *Storage) Keys() []int64 {
+	seen := make(map[int64]bool)
+	var keys []int64
+
+	s.mu.RLock()
+	for key := range s.memStorage {
+		seen[key] = true
+		keys = append(keys, key)
+	}
+	s.mu.RUnlock()
+
+	files, err := ioutil.ReadDir(s.root)
+	if err != nil {
+		log.Println("unable to list stored keys: " + err.Error())
+		return keys
+	}
+	for _, file := range files {
+		key, err := strconv.ParseInt(file.Name(), 10, 64)
+		if err != nil || seen[key] {
+			continue
+		}
+		seen[key] = true
+		keys = append(keys, key)
+	}
+
+	return keys
+}
+
+// Keys sharing a ring position in an ordered namespace are kept together in
+// a bucket, an encoded map from the key name to its data.
+
+func (s *Storage) SaveNamed(key int64, name string, data []byte) ([]byte, error) {
+	s.bucketMu.Lock()
+	defer s.bucketMu.Unlock()
+
+	bucket, err := s.ReadBucket(key)
+	if err != nil {
+		return nil, err
+	}
+	bucket[name] = data
+
+	return s.putBucket(key, bucket)
+}
+
+func (s *Storage) DeleteNamed(key int64, name string) ([]byte, error) {
+	s.bucketMu.Lock()
+	defer s.bucketMu.Unlock()
+
+	bucket, err := s.ReadBucket(key)
+	if err != nil {
+		return nil, err
+	}
+	delete(bucket, name)
+
+	if len(bucket) == 0 {
+		return nil, s.Delete(key)
+	}
+	return s.putBucket(key, bucket)
+}
+
+func (s *Storage) ReadNamed(key int64, name string) ([]byte, error) {
+	bucket, err := s.ReadBucket(key)
+	if err != nil {
+		return nil, err
+	}
+
+	data, ok := bucket[name]
+	if !ok {
+		return nil, errors.New("Key not found")
+	}
+	return data, nil
+}
+
+func (s *Storage) ReadBucket(key int64) (map[string][]byte, error) {
+	bucket := make(map[string][]byte)
+
+	content, err := s.Read(key)
+	if err != nil {
+		if err.Error() == "Key not found" {
+			return bucket, nil
+		}
+		return nil, err
+	}
+
+	if err := json.Unmarshal(content, &bucket); err != nil {
+		return nil, err
+	}
+	return bucket, nil
+}
+
+func (s *Storage) putBucket(key int64, bucket map[string][]byte) ([]byte, error) {
+	content, err := json.Marshal(bucket)
+	if err != nil {
+		return nil, err
+	}
+	return content, s.Put(Entry{Key: key, Data: content})
+}
+
 func (s *Storage) FlushMem() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	for key, value := range s.memStorage {
 		entry := Entry{Key: key, Data: value}
-		err := s.saveDisk(entry)
+		err := s.putDisk(entry)
 		if err != nil {
 			log.Println("error when flushing to disk")
 			log.Println(err.Error())
@@ -114,6 +477,8 @@ func (s *Storage) ReadAsync(key int64, cbuffer chan []byte, ebuffer chan error)
 }
 
 func (s *Storage) saveMem(data Entry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	s.memStorage[data.Key] = data.Data
 	return nil
 }
@@ -121,21 +486,32 @@ func (s *Storage) saveMem(data Entry) error {
 func (s *Storage) saveDisk(data Entry) error {
 	f, err := os.OpenFile(s.root+"/"+fmt.Sprint(data.Key), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
 	if err != nil {
-		log.Println("unable to open/create %v", data.Key)
+		log.Printf("unable to open/create %v", data.Key)
 		return err
 	}
 
 	defer f.Close()
 
 	if _, err := f.Write(data.Data); err != nil {
-		log.Println("unable to write to %v", data.Key)
+		log.Printf("unable to write to %v", data.Key)
+	}
+
+	return err
+}
+
+func (s *Storage) putDisk(data Entry) error {
+	err := ioutil.WriteFile(s.root+"/"+fmt.Sprint(data.Key), data.Data, 0644)
+	if err != nil {
+		log.Printf("unable to write to %v", data.Key)
 	}
 
 	return err
 }
 
 func (s *Storage) readMem(key int64) ([]byte, error) {
+	s.mu.RLock()
 	data, ok := s.memStorage[key]
+	s.mu.RUnlock()
 	if !ok {
 		data, err := s.readDisk(key, -1, 0)
 		if err != nil {
@@ -149,7 +525,7 @@ func (s *Storage) readMem(key int64) ([]byte, error) {
 func (s *Storage) readDisk(key, limit, offset int64) ([]byte, error) {
 	f, err := os.Open(s.root + "/" + fmt.Sprint(key))
 	if err != nil {
-		log.Println("unable to open file %v", fmt.Sprint(key))
+		log.Printf("unable to open file %v", fmt.Sprint(key))
 		log.Println(err)
 		return nil, errors.New("Key not found")
 	}
@@ -184,7 +560,11 @@ func (s *Storage) readDisk(key, limit, offset int64) ([]byte, error) {
 }
 
 func (s *Storage) deleteMem(key int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	delete(s.memStorage, key)
+	// flushed data would otherwise be read back by readMem
+	os.Remove(s.root + "/" + fmt.Sprint(key))
 	return nil
 }
 
@@ -195,7 +575,7 @@ func (s *Storage) deleteDisk(key int64) error {
 func (s *Storage) readDiskAsync(key int64, cbuffer chan []byte, ebuffer chan error) {
 	f, err := os.Open(s.root + "/" + fmt.Sprint(key))
 	if err != nil {
-		log.Println("unable to open file %v", fmt.Sprint(key))
+		log.Printf("unable to open file %v", fmt.Sprint(key))
 		log.Println(err)
 		ebuffer <- errors.New("Key not found")
 	}